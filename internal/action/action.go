@@ -2,16 +2,18 @@ package action
 
 import (
 	"context"
-	"runtime"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/go-to-k/lamver/internal/runtimecatalog"
 	"github.com/go-to-k/lamver/internal/types"
 	"github.com/go-to-k/lamver/pkg/client"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"golang.org/x/sync/errgroup"
-	"golang.org/x/sync/semaphore"
 )
 
 type GetAllRegionsAndRuntimeInput struct {
@@ -19,9 +21,11 @@ type GetAllRegionsAndRuntimeInput struct {
 	EC2           client.EC2Client
 	Lambda        client.LambdaClient
 	DefaultRegion string
+	// Catalog provides the runtime values. If nil, the runtime values of the SDK are used.
+	Catalog *runtimecatalog.Catalog
 }
 
-func GetAllRegionsAndRuntime(input *GetAllRegionsAndRuntimeInput) (regionList []string, runtimeList []string, err error) {
+func GetAllRegionsAndRuntime(input *GetAllRegionsAndRuntimeInput) (regionList []client.Region, runtimeList []string, err error) {
 	eg, ctx := errgroup.WithContext(input.Ctx)
 	eg.Go(func() error {
 		regionList, err = input.EC2.DescribeRegions(ctx)
@@ -32,6 +36,10 @@ func GetAllRegionsAndRuntime(input *GetAllRegionsAndRuntimeInput) (regionList []
 	})
 
 	eg.Go(func() error {
+		if input.Catalog != nil {
+			runtimeList = input.Catalog.Names()
+			return nil
+		}
 		runtimeList = input.Lambda.ListRuntimeValues()
 		return nil
 	})
@@ -43,64 +51,109 @@ func GetAllRegionsAndRuntime(input *GetAllRegionsAndRuntimeInput) (regionList []
 	return regionList, runtimeList, nil
 }
 
+// SplitRegionsByOptIn separates regions that can be scanned from regions not opted in to the account.
+func SplitRegionsByOptIn(regions []client.Region) (enabled []client.Region, disabled []client.Region) {
+	enabled = []client.Region{}
+	disabled = []client.Region{}
+	for _, region := range regions {
+		if region.IsEnabled() {
+			enabled = append(enabled, region)
+		} else {
+			disabled = append(disabled, region)
+		}
+	}
+	return enabled, disabled
+}
+
 type CreateFunctionListInput struct {
 	Ctx           context.Context
 	TargetRegions []string
 	TargetRuntime []string
 	Keyword       string
-	Lambda        client.LambdaClient
+	// Tags filters functions by tags. An empty value matches any value of the key.
+	Tags map[string]string
+	// ModifiedBefore filters functions last modified before the time. The zero value means no limit.
+	ModifiedBefore time.Time
+	// ModifiedAfter filters functions last modified at or after the time. The zero value means no limit.
+	ModifiedAfter time.Time
+	// VPC filters functions by whether they are attached to a VPC.
+	VPC VPCAttachment
+	// Architecture filters functions by the architecture, x86_64 or arm64. An empty value means any.
+	Architecture string
+	// Query filters functions by a query parsed by ParseQuery. If nil, it matches all functions.
+	Query *Query
+	// Audit sets the number of the environment variables and the findings of secret-like ones to each function.
+	// The values of the variables are not kept in the result in any case.
+	Audit bool
+	// SortKeys sorts the functions. If empty, they are sorted by runtime, region and function name.
+	SortKeys []SortKey
+	// Concurrency is the number of regions searched at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	// Listings are the functions already listed by region, such as by ListFunctionsByRegion.
+	// Regions in the listings are not listed again.
+	Listings map[string][]lambdaTypes.FunctionConfiguration
+	Lambda   FunctionLister
+}
+
+// FunctionLister is the part of client.LambdaClient called by CreateFunctionList.
+type FunctionLister interface {
+	ListFunctionsWithRegion(ctx context.Context, region string) ([]lambdaTypes.FunctionConfiguration, error)
+	ListTagsWithRegion(ctx context.Context, region string, functionArn string) (map[string]string, error)
 }
 
-func CreateFunctionList(input *CreateFunctionListInput) ([][]string, error) {
-	functionMap := make(map[string]map[string][][]string, len(input.TargetRuntime))
+// CreateFunctionList searches functions across the target regions, sorted by runtime, region and function name,
+// or by SortKeys if specified.
+func CreateFunctionList(input *CreateFunctionListInput) ([]types.LambdaFunctionData, error) {
+	functionMap := make(map[string]map[string][]*types.LambdaFunctionData, len(input.TargetRuntime))
+
+	filter := &functionFilter{
+		targetRuntime:  input.TargetRuntime,
+		keyword:        input.Keyword,
+		tags:           input.Tags,
+		modifiedBefore: input.ModifiedBefore,
+		modifiedAfter:  input.ModifiedAfter,
+		vpc:            input.VPC,
+		architecture:   input.Architecture,
+		query:          input.Query,
+		audit:          input.Audit,
+	}
 
-	eg, ctx := errgroup.WithContext(input.Ctx)
 	functionCh := make(chan *types.LambdaFunctionData)
-	sem := semaphore.NewWeighted(int64(runtime.NumCPU()))
 	wg := sync.WaitGroup{}
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		for f := range functionCh {
-			if _, exist := functionMap[f.Runtime]; !exist {
-				functionMap[f.Runtime] = make(map[string][][]string, len(input.TargetRegions))
+			runtime := string(f.Runtime)
+			if _, exist := functionMap[runtime]; !exist {
+				functionMap[runtime] = make(map[string][]*types.LambdaFunctionData, len(input.TargetRegions))
 			}
-			functionMap[f.Runtime][f.Region] = append(functionMap[f.Runtime][f.Region], []string{f.FunctionName, f.LastModified})
+			functionMap[runtime][f.Region] = append(functionMap[runtime][f.Region], f)
 		}
 	}()
 
-	for _, region := range input.TargetRegions {
-		region := region
-		if err := sem.Acquire(ctx, 1); err != nil {
-			return [][]string{}, err
-		}
-		eg.Go(func() error {
-			defer sem.Release(1)
-			return putToFunctionChannelByRegion(
-				ctx,
-				region,
-				input.TargetRuntime,
-				input.Keyword,
-				functionCh,
-				input.Lambda,
-			)
-		})
-	}
-
-	go func() {
-		//nolint:errcheck
-		eg.Wait()
-		close(functionCh)
-	}()
-
-	if err := eg.Wait(); err != nil {
-		return [][]string{}, err
+	err := forEachRegion(input.Ctx, input.Concurrency, input.TargetRegions, func(ctx context.Context, region string) error {
+		return putToFunctionChannelByRegion(
+			ctx,
+			region,
+			filter,
+			functionCh,
+			input.Listings,
+			input.Lambda,
+		)
+	})
+	close(functionCh)
+	if err != nil {
+		return []types.LambdaFunctionData{}, err
 	}
 
 	wg.Wait() // for functionMap race
 
 	sortedFunctionList := sortAndSetFunctionList(input.TargetRegions, input.TargetRuntime, functionMap)
+	if len(input.SortKeys) > 0 {
+		SortFunctions(sortedFunctionList, input.SortKeys, input.TargetRuntime)
+	}
 
 	return sortedFunctionList, nil
 }
@@ -108,20 +161,24 @@ func CreateFunctionList(input *CreateFunctionListInput) ([][]string, error) {
 func putToFunctionChannelByRegion(
 	ctx context.Context,
 	region string,
-	targetRuntime []string,
-	keyword string,
+	filter *functionFilter,
 	functionCh chan *types.LambdaFunctionData,
-	lambda client.LambdaClient,
+	listings map[string][]lambdaTypes.FunctionConfiguration,
+	lambda FunctionLister,
 ) error {
-	functions, err := lambda.ListFunctionsWithRegion(ctx, region)
-	if err != nil {
-		return err
+	var err error
+	functions, ok := listings[region]
+	if !ok {
+		functions, err = lambda.ListFunctionsWithRegion(ctx, region)
+		if err != nil {
+			return err
+		}
 	}
 
-	lowerKeyword := strings.ToLower(keyword)
+	lowerKeyword := strings.ToLower(filter.keyword)
 
 	for _, function := range functions {
-		for _, runtime := range targetRuntime {
+		for _, runtime := range filter.targetRuntime {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -132,14 +189,81 @@ func putToFunctionChannelByRegion(
 			}
 			// for case-insensitive
 			lowerFunctionName := strings.ToLower(*function.FunctionName)
-			if strings.Contains(lowerFunctionName, lowerKeyword) {
-				functionCh <- &types.LambdaFunctionData{
-					Runtime:      runtime,
-					Region:       region,
-					FunctionName: *function.FunctionName,
-					LastModified: *function.LastModified,
+			if !strings.Contains(lowerFunctionName, lowerKeyword) {
+				break
+			}
+			// the zero time is kept if Lambda returns an unexpected format, not to stop the search
+			lastModified, _ := time.Parse(client.LambdaTimestampLayout, aws.ToString(function.LastModified))
+			if !filter.matchLastModified(lastModified) {
+				break
+			}
+			var vpcConfig lambdaTypes.VpcConfigResponse
+			if function.VpcConfig != nil {
+				vpcConfig = *function.VpcConfig
+			}
+			vpcID := aws.ToString(vpcConfig.VpcId)
+			if !filter.matchVPC(vpcID) {
+				break
+			}
+			if !filter.matchArchitecture(function.Architectures) {
+				break
+			}
+			// tags need an API call per function, so they are listed once and only if needed
+			var functionTags map[string]string
+			if len(filter.tags) > 0 || filter.query.NeedsTags() {
+				functionTags, err = lambda.ListTagsWithRegion(ctx, region, aws.ToString(function.FunctionArn))
+				if err != nil {
+					return err
+				}
+				if functionTags == nil {
+					functionTags = map[string]string{}
+				}
+			}
+			if len(filter.tags) > 0 && !matchTags(functionTags, filter.tags) {
+				break
+			}
+			if !filter.query.IsEmpty() {
+				target := &queryTarget{
+					name:         aws.ToString(function.FunctionName),
+					runtime:      string(function.Runtime),
+					region:       region,
+					lastModified: lastModified,
+					tags:         functionTags,
 				}
+				for _, architecture := range function.Architectures {
+					target.architectures = append(target.architectures, string(architecture))
+				}
+				if !filter.query.matches(target) {
+					break
+				}
+			}
+			data := &types.LambdaFunctionData{
+				Runtime:      function.Runtime,
+				Region:       region,
+				FunctionName: *function.FunctionName,
+				LastModified: lastModified,
+				CodeSize:     function.CodeSize,
+				FunctionArn:  aws.ToString(function.FunctionArn),
+				ConsoleURL:   client.GetLambdaConsoleURL(region, *function.FunctionName),
+				// kept for the later steps not to list them again
+				Tags: functionTags,
+			}
+			if filter.audit {
+				var environment map[string]string
+				if function.Environment != nil {
+					environment = function.Environment.Variables
+				}
+				auditFunction(data, environment)
+			}
+			data.KMSKeyArn = aws.ToString(function.KMSKeyArn)
+			setFunctionConfigs(data, function)
+			// an empty VpcConfig may be returned for functions not attached to a VPC
+			if vpcID != "" {
+				data.VpcID = vpcID
+				data.SubnetIDs = vpcConfig.SubnetIds
+				data.SecurityGroupIDs = vpcConfig.SecurityGroupIds
 			}
+			functionCh <- data
 			break
 		}
 	}
@@ -147,8 +271,47 @@ func putToFunctionChannelByRegion(
 	return nil
 }
 
-func sortAndSetFunctionList(regionList []string, runtimeList []string, functionMap map[string]map[string][][]string) [][]string {
-	var functionList [][]string
+func setFunctionConfigs(data *types.LambdaFunctionData, function lambdaTypes.FunctionConfiguration) {
+	if function.TracingConfig != nil {
+		data.TracingMode = string(function.TracingConfig.Mode)
+	}
+	if function.SnapStart != nil {
+		data.SnapStart = string(function.SnapStart.ApplyOn)
+	}
+	if function.LoggingConfig != nil {
+		data.LogFormat = string(function.LoggingConfig.LogFormat)
+		data.LogGroup = aws.ToString(function.LoggingConfig.LogGroup)
+	}
+	if function.DeadLetterConfig != nil {
+		data.DeadLetterTargetArn = aws.ToString(function.DeadLetterConfig.TargetArn)
+	}
+	for _, architecture := range function.Architectures {
+		data.Architectures = append(data.Architectures, string(architecture))
+	}
+	for _, layer := range function.Layers {
+		data.Layers = append(data.Layers, aws.ToString(layer.Arn))
+	}
+}
+
+func matchTags(functionTags map[string]string, tags map[string]string) bool {
+	for key, value := range tags {
+		v, ok := functionTags[key]
+		if !ok {
+			return false
+		}
+		if value != "" && v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func sortAndSetFunctionList(
+	regionList []string,
+	runtimeList []string,
+	functionMap map[string]map[string][]*types.LambdaFunctionData,
+) []types.LambdaFunctionData {
+	var functionList []types.LambdaFunctionData
 
 	for _, runtime := range runtimeList {
 		if _, exist := functionMap[runtime]; !exist {
@@ -160,15 +323,11 @@ func sortAndSetFunctionList(regionList []string, runtimeList []string, functionM
 			}
 
 			sort.Slice(functionMap[runtime][region], func(i, j int) bool {
-				return functionMap[runtime][region][i][0] < functionMap[runtime][region][j][0]
+				return functionMap[runtime][region][i].FunctionName < functionMap[runtime][region][j].FunctionName
 			})
 
 			for _, f := range functionMap[runtime][region] {
-				var data []string
-				data = append(data, runtime)
-				data = append(data, region)
-				data = append(data, f...)
-				functionList = append(functionList, data)
+				functionList = append(functionList, *f)
 			}
 		}
 	}
@@ -6,7 +6,9 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-to-k/lamver/internal/runtimecatalog"
 	"github.com/go-to-k/lamver/internal/types"
 	"github.com/go-to-k/lamver/pkg/client"
 
@@ -15,10 +17,19 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+func mustParseLastModified(lastModified string) time.Time {
+	t, err := time.Parse(client.LambdaTimestampLayout, lastModified)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
 func TestGetAllRegionsAndRuntime(t *testing.T) {
 	type args struct {
-		ctx    context.Context
-		region string
+		ctx     context.Context
+		region  string
+		catalog *runtimecatalog.Catalog
 	}
 
 	tests := []struct {
@@ -26,7 +37,7 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 		args                      args
 		prepareMockEC2ClientFn    func(m *client.MockEC2Client)
 		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
-		wantRegionList            []string
+		wantRegionList            []client.Region
 		wantRuntimeList           []string
 		wantErr                   bool
 	}{
@@ -38,9 +49,9 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 			},
 			prepareMockEC2ClientFn: func(m *client.MockEC2Client) {
 				m.EXPECT().DescribeRegions(gomock.Any()).Return(
-					[]string{
-						"ap-northeast-1",
-						"us-east-1",
+					[]client.Region{
+						{Name: "ap-northeast-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+						{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
 					}, nil,
 				)
 			},
@@ -52,9 +63,9 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 					},
 				)
 			},
-			wantRegionList: []string{
-				"ap-northeast-1",
-				"us-east-1",
+			wantRegionList: []client.Region{
+				{Name: "ap-northeast-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+				{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
 			},
 			wantRuntimeList: []string{
 				"go1.x",
@@ -62,6 +73,35 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "GetAllRegionsAndRuntime success with a runtime catalog",
+			args: args{
+				ctx:    context.Background(),
+				region: "us-east-1",
+				catalog: &runtimecatalog.Catalog{
+					Runtimes: []runtimecatalog.Runtime{
+						{Name: "nodejs26.x"},
+						{Name: "nodejs18.x"},
+					},
+				},
+			},
+			prepareMockEC2ClientFn: func(m *client.MockEC2Client) {
+				m.EXPECT().DescribeRegions(gomock.Any()).Return(
+					[]client.Region{
+						{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+					}, nil,
+				)
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {},
+			wantRegionList: []client.Region{
+				{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+			},
+			wantRuntimeList: []string{
+				"nodejs18.x",
+				"nodejs26.x",
+			},
+			wantErr: false,
+		},
 		{
 			name: "GetAllRegionsAndRuntime fail by DescribeRegions Error",
 			args: args{
@@ -70,7 +110,7 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 			},
 			prepareMockEC2ClientFn: func(m *client.MockEC2Client) {
 				m.EXPECT().DescribeRegions(gomock.Any()).Return(
-					[]string{}, fmt.Errorf("DescribeRegionsError"),
+					[]client.Region{}, fmt.Errorf("DescribeRegionsError"),
 				)
 			},
 			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
@@ -81,7 +121,7 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 					},
 				)
 			},
-			wantRegionList: []string{},
+			wantRegionList: []client.Region{},
 			wantRuntimeList: []string{
 				"go1.x",
 				"nodejs18.x",
@@ -103,6 +143,7 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 				EC2:           ec2ClientMock,
 				Lambda:        lambdaClientMock,
 				DefaultRegion: tt.args.region,
+				Catalog:       tt.args.catalog,
 			}
 
 			gotRegionList, gotRuntimeList, err := GetAllRegionsAndRuntime(input)
@@ -120,19 +161,73 @@ func TestGetAllRegionsAndRuntime(t *testing.T) {
 	}
 }
 
+func TestSplitRegionsByOptIn(t *testing.T) {
+	tests := []struct {
+		name         string
+		regions      []client.Region
+		wantEnabled  []client.Region
+		wantDisabled []client.Region
+	}{
+		{
+			name: "SplitRegionsByOptIn success",
+			regions: []client.Region{
+				{Name: "ap-east-1", OptInStatus: client.RegionOptedIn},
+				{Name: "me-south-1", OptInStatus: client.RegionNotOptedIn},
+				{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired},
+			},
+			wantEnabled: []client.Region{
+				{Name: "ap-east-1", OptInStatus: client.RegionOptedIn},
+				{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired},
+			},
+			wantDisabled: []client.Region{
+				{Name: "me-south-1", OptInStatus: client.RegionNotOptedIn},
+			},
+		},
+		{
+			name: "SplitRegionsByOptIn success if all regions are enabled",
+			regions: []client.Region{
+				{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired},
+			},
+			wantEnabled: []client.Region{
+				{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired},
+			},
+			wantDisabled: []client.Region{},
+		},
+		{
+			name:         "SplitRegionsByOptIn success if regions are empty",
+			regions:      []client.Region{},
+			wantEnabled:  []client.Region{},
+			wantDisabled: []client.Region{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotEnabled, gotDisabled := SplitRegionsByOptIn(tt.regions)
+			if !reflect.DeepEqual(gotEnabled, tt.wantEnabled) {
+				t.Errorf("SplitRegionsByOptIn() gotEnabled = %v, want %v", gotEnabled, tt.wantEnabled)
+			}
+			if !reflect.DeepEqual(gotDisabled, tt.wantDisabled) {
+				t.Errorf("SplitRegionsByOptIn() gotDisabled = %v, want %v", gotDisabled, tt.wantDisabled)
+			}
+		})
+	}
+}
+
 func TestCreateFunctionList(t *testing.T) {
 	type args struct {
 		ctx           context.Context
 		targetRegions []string
 		targetRuntime []string
 		keyword       string
+		sortKeys      []SortKey
+		audit         bool
 	}
 
 	tests := []struct {
 		name                      string
 		args                      args
 		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
-		want                      [][]string
+		want                      []types.LambdaFunctionData
 		wantErr                   bool
 	}{
 		{
@@ -183,15 +278,16 @@ func TestCreateFunctionList(t *testing.T) {
 							FunctionName: aws.String("Function6"),
 							Runtime:      lambdaTypes.RuntimeNodejs18x,
 							LastModified: aws.String("2022-12-22T09:47:43.728+0000"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-2:123456789012:function:Function6"),
 						},
 					}, nil,
 				)
 			},
-			want: [][]string{
-				{"nodejs18.x", "us-east-2", "Function6", "2022-12-22T09:47:43.728+0000"},
-				{"nodejs", "ap-northeast-1", "Function1", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "us-east-1", "Function3", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "us-east-2", "Function5", "2022-12-21T09:47:43.728+0000"},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-2", FunctionName: "Function6", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000"), FunctionArn: "arn:aws:lambda:us-east-2:123456789012:function:Function6", ConsoleURL: "https://us-east-2.console.aws.amazon.com/lambda/home?region=us-east-2#/functions/Function6"},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://ap-northeast-1.console.aws.amazon.com/lambda/home?region=ap-northeast-1#/functions/Function1"},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function3"},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-2", FunctionName: "Function5", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-2.console.aws.amazon.com/lambda/home?region=us-east-2#/functions/Function5"},
 			},
 			wantErr: false,
 		},
@@ -214,7 +310,7 @@ func TestCreateFunctionList(t *testing.T) {
 					[]lambdaTypes.FunctionConfiguration{}, nil,
 				)
 			},
-			want:    [][]string{},
+			want:    []types.LambdaFunctionData{},
 			wantErr: false,
 		},
 		{
@@ -269,8 +365,8 @@ func TestCreateFunctionList(t *testing.T) {
 					}, nil,
 				)
 			},
-			want: [][]string{
-				{"nodejs", "us-east-1", "Function3", "2022-12-21T09:47:43.728+0000"},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function3"},
 			},
 			wantErr: false,
 		},
@@ -326,8 +422,8 @@ func TestCreateFunctionList(t *testing.T) {
 					}, nil,
 				)
 			},
-			want: [][]string{
-				{"nodejs", "us-east-1", "Function3", "2022-12-21T09:47:43.728+0000"},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function3"},
 			},
 			wantErr: false,
 		},
@@ -383,8 +479,8 @@ func TestCreateFunctionList(t *testing.T) {
 					}, nil,
 				)
 			},
-			want: [][]string{
-				{"nodejs", "us-east-1", "Function3", "2022-12-21T09:47:43.728+0000"},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function3"},
 			},
 			wantErr: false,
 		},
@@ -440,7 +536,7 @@ func TestCreateFunctionList(t *testing.T) {
 					}, nil,
 				)
 			},
-			want:    [][]string{},
+			want:    []types.LambdaFunctionData{},
 			wantErr: false,
 		},
 		{
@@ -484,10 +580,113 @@ func TestCreateFunctionList(t *testing.T) {
 					}, nil,
 				)
 			},
-			want: [][]string{
-				{"nodejs18.x", "us-east-2", "Function6", "2022-12-22T09:47:43.728+0000"},
-				{"nodejs", "us-east-1", "Function3", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "us-east-2", "Function5", "2022-12-21T09:47:43.728+0000"},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-2", FunctionName: "Function6", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000"), ConsoleURL: "https://us-east-2.console.aws.amazon.com/lambda/home?region=us-east-2#/functions/Function6"},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function3"},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-2", FunctionName: "Function5", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-2.console.aws.amazon.com/lambda/home?region=us-east-2#/functions/Function5"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateFunctionList success with sort keys",
+			args: args{
+				ctx:           context.Background(),
+				targetRegions: []string{"ap-northeast-1", "us-east-1"},
+				targetRuntime: []string{"nodejs18.x", "nodejs"},
+				keyword:       "",
+				sortKeys:      []SortKey{{Field: SortFieldLastModified, Descending: true}},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "ap-northeast-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function2"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-20T09:47:43.728+0000"),
+							CodeSize:     1024,
+						},
+						{
+							FunctionName: aws.String("Function3"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+							LastModified: aws.String("2022-12-22T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000"), ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function3"},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://ap-northeast-1.console.aws.amazon.com/lambda/home?region=ap-northeast-1#/functions/Function1"},
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-1", FunctionName: "Function2", LastModified: mustParseLastModified("2022-12-20T09:47:43.728+0000"), CodeSize: 1024, ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateFunctionList success without keeping environment variables",
+			args: args{
+				ctx:           context.Background(),
+				targetRegions: []string{"us-east-1"},
+				targetRuntime: []string{"nodejs18.x"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+							Environment: &lambdaTypes.EnvironmentResponse{
+								Variables: map[string]string{"DB_PASSWORD": "hunter2", "LOG_LEVEL": "info"},
+							},
+						},
+					}, nil,
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"), ConsoleURL: "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function1"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "CreateFunctionList success with audit",
+			args: args{
+				ctx:           context.Background(),
+				targetRegions: []string{"us-east-1"},
+				targetRuntime: []string{"nodejs18.x"},
+				audit:         true,
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+							Environment: &lambdaTypes.EnvironmentResponse{
+								Variables: map[string]string{"DB_PASSWORD": "hunter2", "LOG_LEVEL": "info"},
+							},
+						},
+					}, nil,
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{
+					Runtime:      lambdaTypes.RuntimeNodejs18x,
+					Region:       "us-east-1",
+					FunctionName: "Function1",
+					LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000"),
+					ConsoleURL:   "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function1",
+					EnvVariables: 2,
+					EnvFindings:  []types.EnvFinding{{Name: "DB_PASSWORD", Reason: ReasonSecretName}},
+				},
 			},
 			wantErr: false,
 		},
@@ -504,6 +703,8 @@ func TestCreateFunctionList(t *testing.T) {
 				TargetRegions: tt.args.targetRegions,
 				TargetRuntime: tt.args.targetRuntime,
 				Keyword:       tt.args.keyword,
+				SortKeys:      tt.args.sortKeys,
+				Audit:         tt.args.audit,
 				Lambda:        lambdaClientMock,
 			}
 
@@ -525,6 +726,11 @@ func Test_putToFunctionChannelByRegion(t *testing.T) {
 		region        string
 		targetRuntime []string
 		keyword       string
+		tags          map[string]string
+		modifiedAfter time.Time
+		vpc           VPCAttachment
+		listings      map[string][]lambdaTypes.FunctionConfiguration
+		query         string
 		functionCh    chan *types.LambdaFunctionData
 	}
 
@@ -563,6 +769,33 @@ func Test_putToFunctionChannelByRegion(t *testing.T) {
 			putCount: 1,
 			wantErr:  false,
 		},
+		{
+			name: "putToFunctionChannelByRegion success with listings instead of listing functions",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs18.x", "nodejs26.x"},
+				keyword:       "",
+				listings: map[string][]lambdaTypes.FunctionConfiguration{
+					"us-east-1": {
+						{
+							FunctionName: aws.String("Function1"),
+							Runtime:      lambdaTypes.Runtime("nodejs26.x"),
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+						{
+							FunctionName: aws.String("Function2"),
+							Runtime:      lambdaTypes.RuntimeGo1x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+					},
+				},
+				functionCh: make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {},
+			putCount:                  1,
+			wantErr:                   false,
+		},
 		{
 			name: "putToFunctionChannelByRegion success if there is no corresponding runtime",
 			args: args{
@@ -720,6 +953,255 @@ func Test_putToFunctionChannelByRegion(t *testing.T) {
 			putCount: 0,
 			wantErr:  false,
 		},
+		{
+			name: "putToFunctionChannelByRegion success if tags given",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs18.x", "nodejs"},
+				keyword:       "",
+				tags:          map[string]string{"team": "core", "env": ""},
+				functionCh:    make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function3"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function3"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+						{
+							FunctionName: aws.String("Function4"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function4"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+						{
+							FunctionName: aws.String("Function5"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function5"),
+							Runtime:      lambdaTypes.RuntimeGo1x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function3").Return(
+					map[string]string{"team": "core", "env": "prod"}, nil,
+				)
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function4").Return(
+					map[string]string{"team": "other", "env": "prod"}, nil,
+				)
+			},
+			putCount: 1,
+			wantErr:  false,
+		},
+		{
+			name: "putToFunctionChannelByRegion fail by ListTagsWithRegion Error",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs"},
+				keyword:       "",
+				tags:          map[string]string{"team": "core"},
+				functionCh:    make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function3"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function3"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function3").Return(
+					map[string]string{}, fmt.Errorf("ListTagsError"),
+				)
+			},
+			putCount: 0,
+			wantErr:  true,
+		},
+		{
+			name: "putToFunctionChannelByRegion success if modified after given",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs18.x", "nodejs"},
+				keyword:       "",
+				modifiedAfter: mustParseLastModified("2022-12-22T00:00:00.000+0000"),
+				functionCh:    make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function3"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+						{
+							FunctionName: aws.String("Function4"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-22T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+			},
+			putCount: 1,
+			wantErr:  false,
+		},
+		{
+			name: "putToFunctionChannelByRegion success if in VPC given",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs18.x", "nodejs"},
+				keyword:       "",
+				vpc:           VPCAttachmentAttached,
+				functionCh:    make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							VpcConfig: &lambdaTypes.VpcConfigResponse{
+								VpcId:            aws.String("vpc-12345678"),
+								SubnetIds:        []string{"subnet-12345678"},
+								SecurityGroupIds: []string{"sg-12345678"},
+							},
+						},
+						{
+							FunctionName: aws.String("Function2"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							VpcConfig: &lambdaTypes.VpcConfigResponse{
+								VpcId:            aws.String(""),
+								SubnetIds:        []string{},
+								SecurityGroupIds: []string{},
+							},
+						},
+						{
+							FunctionName: aws.String("Function3"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+						},
+					}, nil,
+				)
+			},
+			putCount: 1,
+			wantErr:  false,
+		},
+		{
+			name: "putToFunctionChannelByRegion success if no VPC given",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs18.x", "nodejs"},
+				keyword:       "",
+				vpc:           VPCAttachmentDetached,
+				functionCh:    make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							VpcConfig: &lambdaTypes.VpcConfigResponse{
+								VpcId:            aws.String("vpc-12345678"),
+								SubnetIds:        []string{"subnet-12345678"},
+								SecurityGroupIds: []string{"sg-12345678"},
+							},
+						},
+						{
+							FunctionName: aws.String("Function2"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							VpcConfig: &lambdaTypes.VpcConfigResponse{
+								VpcId:            aws.String(""),
+								SubnetIds:        []string{},
+								SecurityGroupIds: []string{},
+							},
+						},
+						{
+							FunctionName: aws.String("Function3"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+						},
+					}, nil,
+				)
+			},
+			putCount: 2,
+			wantErr:  false,
+		},
+		{
+			name: "putToFunctionChannelByRegion success if query given",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs18.x", "nodejs"},
+				query:         "name:function* -name:function2 modified<2023-01-01",
+				functionCh:    make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+						{
+							FunctionName: aws.String("Function2"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+						{
+							FunctionName: aws.String("Function3"),
+							Runtime:      lambdaTypes.RuntimeNodejs,
+							LastModified: aws.String("2023-12-21T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+			},
+			putCount: 1,
+			wantErr:  false,
+		},
+		{
+			name: "putToFunctionChannelByRegion success if query with tags given",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				targetRuntime: []string{"nodejs18.x", "nodejs"},
+				query:         "tag:team=core",
+				functionCh:    make(chan *types.LambdaFunctionData),
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+						},
+						{
+							FunctionName: aws.String("Function2"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function2"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+						},
+					}, nil,
+				)
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function1").Return(
+					map[string]string{"team": "core"}, nil,
+				)
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function2").Return(
+					map[string]string{}, nil,
+				)
+			},
+			putCount: 1,
+			wantErr:  false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -728,6 +1210,11 @@ func Test_putToFunctionChannelByRegion(t *testing.T) {
 
 			tt.prepareMockLambdaClientFn(lambdaClientMock)
 
+			query, err := ParseQuery(tt.args.query)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+
 			putCount := 0
 			ctx, cancel := context.WithCancel(tt.args.ctx)
 			ch := tt.args.functionCh
@@ -746,7 +1233,15 @@ func Test_putToFunctionChannelByRegion(t *testing.T) {
 				}
 			}()
 
-			if err := putToFunctionChannelByRegion(ctx, tt.args.region, tt.args.targetRuntime, tt.args.keyword, ch, lambdaClientMock); (err != nil) != tt.wantErr {
+			filter := &functionFilter{
+				targetRuntime: tt.args.targetRuntime,
+				keyword:       tt.args.keyword,
+				tags:          tt.args.tags,
+				modifiedAfter: tt.args.modifiedAfter,
+				vpc:           tt.args.vpc,
+				query:         query,
+			}
+			if err := putToFunctionChannelByRegion(ctx, tt.args.region, filter, ch, tt.args.listings, lambdaClientMock); (err != nil) != tt.wantErr {
 				t.Errorf("putToFunctionChannelByRegion() error = %v, wantErr %v", err, tt.wantErr)
 				cancel()
 				return
@@ -764,42 +1259,42 @@ func Test_sortAndSetFunctionList(t *testing.T) {
 	type args struct {
 		regionList  []string
 		runtimeList []string
-		functionMap map[string]map[string][][]string
+		functionMap map[string]map[string][]*types.LambdaFunctionData
 	}
 	tests := []struct {
 		name string
 		args args
-		want [][]string
+		want []types.LambdaFunctionData
 	}{
 		{
 			name: "sortAndSetFunctionList success",
 			args: args{
 				regionList:  []string{"ap-northeast-1", "us-east-1", "us-east-2"},
 				runtimeList: []string{"nodejs", "nodejs18.x"},
-				functionMap: map[string]map[string][][]string{
+				functionMap: map[string]map[string][]*types.LambdaFunctionData{
 					"nodejs": {
 						"ap-northeast-1": {
-							[]string{"Function1", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 						"us-east-1": {
-							[]string{"Function1", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 					},
 					"nodejs18.x": {
 						"ap-northeast-1": {
-							[]string{"Function3", "2022-12-22T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
 						},
 						"us-east-2": {
-							[]string{"Function3", "2022-12-22T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-2", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
 						},
 					},
 				},
 			},
-			want: [][]string{
-				{"nodejs", "ap-northeast-1", "Function1", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "us-east-1", "Function1", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs18.x", "ap-northeast-1", "Function3", "2022-12-22T09:47:43.728+0000"},
-				{"nodejs18.x", "us-east-2", "Function3", "2022-12-22T09:47:43.728+0000"},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-2", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
 			},
 		},
 		{
@@ -807,42 +1302,42 @@ func Test_sortAndSetFunctionList(t *testing.T) {
 			args: args{
 				regionList:  []string{"ap-northeast-1", "us-east-1", "us-east-2"},
 				runtimeList: []string{"nodejs", "nodejs18.x"},
-				functionMap: map[string]map[string][][]string{
+				functionMap: map[string]map[string][]*types.LambdaFunctionData{
 					"nodejs": {
 						"ap-northeast-1": {
-							[]string{"Function-A", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function-c", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function-b", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function-B", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function-a", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function-1", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-A", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-c", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-b", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-B", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-a", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 						"us-east-1": {
-							[]string{"Function-b-1", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function-a-2", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function-b-1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function-a-2", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 					},
 					"nodejs18.x": {
 						"ap-northeast-1": {
-							[]string{"Function-a-3", "2022-12-21T09:47:43.728+0000"},
-							[]string{"Function-a-0", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function-a-3", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function-a-0", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 					},
 				},
 			},
-			want: [][]string{
-				{"nodejs", "ap-northeast-1", "Function", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "ap-northeast-1", "Function-1", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "ap-northeast-1", "Function-A", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "ap-northeast-1", "Function-B", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "ap-northeast-1", "Function-a", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "ap-northeast-1", "Function-b", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "ap-northeast-1", "Function-c", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "us-east-1", "Function-a-2", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs", "us-east-1", "Function-b-1", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs18.x", "ap-northeast-1", "Function-a-0", "2022-12-21T09:47:43.728+0000"},
-				{"nodejs18.x", "ap-northeast-1", "Function-a-3", "2022-12-21T09:47:43.728+0000"},
+			want: []types.LambdaFunctionData{
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-A", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-B", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-a", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-b", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function-c", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function-a-2", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function-b-1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function-a-0", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
+				{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function-a-3", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 			},
 		},
 		{
@@ -850,61 +1345,61 @@ func Test_sortAndSetFunctionList(t *testing.T) {
 			args: args{
 				regionList:  []string{"ap-northeast-1", "us-east-1", "us-east-2"},
 				runtimeList: []string{},
-				functionMap: map[string]map[string][][]string{
+				functionMap: map[string]map[string][]*types.LambdaFunctionData{
 					"nodejs": {
 						"ap-northeast-1": {
-							[]string{"Function1", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 						"us-east-1": {
-							[]string{"Function1", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 					},
 					"nodejs18.x": {
 						"ap-northeast-1": {
-							[]string{"Function3", "2022-12-22T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
 						},
 						"us-east-2": {
-							[]string{"Function3", "2022-12-22T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-2", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
 						},
 					},
 				},
 			},
-			want: [][]string{},
+			want: []types.LambdaFunctionData{},
 		},
 		{
 			name: "sortAndSetFunctionList success if regionList is empty",
 			args: args{
 				regionList:  []string{},
 				runtimeList: []string{"nodejs", "nodejs18.x"},
-				functionMap: map[string]map[string][][]string{
+				functionMap: map[string]map[string][]*types.LambdaFunctionData{
 					"nodejs": {
 						"ap-northeast-1": {
-							[]string{"Function1", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 						"us-east-1": {
-							[]string{"Function1", "2022-12-21T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function1", LastModified: mustParseLastModified("2022-12-21T09:47:43.728+0000")},
 						},
 					},
 					"nodejs18.x": {
 						"ap-northeast-1": {
-							[]string{"Function3", "2022-12-22T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
 						},
 						"us-east-2": {
-							[]string{"Function3", "2022-12-22T09:47:43.728+0000"},
+							{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-2", FunctionName: "Function3", LastModified: mustParseLastModified("2022-12-22T09:47:43.728+0000")},
 						},
 					},
 				},
 			},
-			want: [][]string{},
+			want: []types.LambdaFunctionData{},
 		},
 		{
 			name: "sortAndSetFunctionList success if functionMap is empty",
 			args: args{
 				regionList:  []string{"ap-northeast-1", "us-east-1", "us-east-2"},
 				runtimeList: []string{"nodejs", "nodejs18.x"},
-				functionMap: map[string]map[string][][]string{},
+				functionMap: map[string]map[string][]*types.LambdaFunctionData{},
 			},
-			want: [][]string{},
+			want: []types.LambdaFunctionData{},
 		},
 	}
 	for _, tt := range tests {
@@ -916,3 +1411,51 @@ func Test_sortAndSetFunctionList(t *testing.T) {
 		})
 	}
 }
+
+func Test_setFunctionConfigs(t *testing.T) {
+	tests := []struct {
+		name     string
+		function lambdaTypes.FunctionConfiguration
+		want     types.LambdaFunctionData
+	}{
+		{
+			name: "setFunctionConfigs with all configurations",
+			function: lambdaTypes.FunctionConfiguration{
+				TracingConfig: &lambdaTypes.TracingConfigResponse{Mode: lambdaTypes.TracingModeActive},
+				SnapStart:     &lambdaTypes.SnapStartResponse{ApplyOn: lambdaTypes.SnapStartApplyOnPublishedVersions},
+				LoggingConfig: &lambdaTypes.LoggingConfig{
+					LogFormat: lambdaTypes.LogFormatJson,
+					LogGroup:  aws.String("/aws/lambda/Function1"),
+				},
+				DeadLetterConfig: &lambdaTypes.DeadLetterConfig{TargetArn: aws.String("arn:aws:sqs:us-east-1:123456789012:dlq")},
+				Architectures:    []lambdaTypes.Architecture{lambdaTypes.ArchitectureArm64},
+				Layers: []lambdaTypes.Layer{
+					{Arn: aws.String("arn:aws:lambda:us-east-1:123456789012:layer:Layer1:1")},
+				},
+			},
+			want: types.LambdaFunctionData{
+				TracingMode:         "Active",
+				SnapStart:           "PublishedVersions",
+				LogFormat:           "JSON",
+				LogGroup:            "/aws/lambda/Function1",
+				DeadLetterTargetArn: "arn:aws:sqs:us-east-1:123456789012:dlq",
+				Architectures:       []string{"arm64"},
+				Layers:              []string{"arn:aws:lambda:us-east-1:123456789012:layer:Layer1:1"},
+			},
+		},
+		{
+			name:     "setFunctionConfigs with no configurations",
+			function: lambdaTypes.FunctionConfiguration{},
+			want:     types.LambdaFunctionData{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := types.LambdaFunctionData{}
+			setFunctionConfigs(&got, tt.function)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setFunctionConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package action
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-to-k/lamver/internal/types"
+)
+
+const (
+	ReasonAWSAccessKeyID = "AWS access key ID"
+	ReasonPrivateKey     = "private key"
+	ReasonToken          = "token format"
+	ReasonSecretName     = "secret-like name"
+	ReasonHighEntropy    = "high-entropy value"
+
+	// highEntropyThreshold is the Shannon entropy in bits per character above which a value looks random.
+	highEntropyThreshold = 4.0
+	highEntropyMinLength = 20
+)
+
+var (
+	awsAccessKeyIDPattern = regexp.MustCompile(`^(AKIA|ASIA)[0-9A-Z]{16}$`)
+	privateKeyPattern     = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+	tokenPatterns         = []*regexp.Regexp{
+		regexp.MustCompile(`^gh[pousr]_[A-Za-z0-9]{36,}$`),                           // GitHub
+		regexp.MustCompile(`^github_pat_[A-Za-z0-9_]{22,}$`),                         // GitHub fine-grained
+		regexp.MustCompile(`^xox[abposr]-[A-Za-z0-9-]{10,}$`),                        // Slack
+		regexp.MustCompile(`^sk_live_[A-Za-z0-9]{16,}$`),                             // Stripe
+		regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`), // JWT
+	}
+	secretNameKeywords = []string{
+		"SECRET",
+		"PASSWORD",
+		"PASSWD",
+		"TOKEN",
+		"APIKEY",
+		"API_KEY",
+		"ACCESS_KEY",
+		"PRIVATE_KEY",
+		"CREDENTIAL",
+	}
+	// referencePrefixes are values pointing to a secret store instead of holding a secret.
+	referencePrefixes = []string{
+		"arn:",
+		"{{resolve:",
+		"ssm:",
+		"secretsmanager:",
+	}
+)
+
+// CountEnvFindings returns the number of the environment variables that look like secrets in the functions
+// audited by CreateFunctionListInput.Audit, and of the functions with them.
+func CountEnvFindings(functions []types.LambdaFunctionData) (findingCount int, functionCount int) {
+	for _, f := range functions {
+		if len(f.EnvFindings) > 0 {
+			findingCount += len(f.EnvFindings)
+			functionCount++
+		}
+	}
+	return findingCount, functionCount
+}
+
+// auditFunction sets the number of the environment variables and those that look like secrets to the function,
+// without keeping the values.
+func auditFunction(data *types.LambdaFunctionData, environment map[string]string) {
+	data.EnvVariables = len(environment)
+	data.EnvFindings = auditEnvironment(environment)
+}
+
+func auditEnvironment(environment map[string]string) []types.EnvFinding {
+	findings := []types.EnvFinding{}
+	for name, value := range environment {
+		if reason := detectSecret(name, value); reason != "" {
+			findings = append(findings, types.EnvFinding{Name: name, Reason: reason})
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Name < findings[j].Name
+	})
+	return findings
+}
+
+// detectSecret returns the reason why the environment variable looks like a secret, or an empty string.
+// Value formats are checked before the name, because they are more specific.
+func detectSecret(name string, value string) string {
+	if value == "" || isSecretReference(value) {
+		return ""
+	}
+
+	if awsAccessKeyIDPattern.MatchString(value) {
+		return ReasonAWSAccessKeyID
+	}
+	if privateKeyPattern.MatchString(value) {
+		return ReasonPrivateKey
+	}
+	for _, pattern := range tokenPatterns {
+		if pattern.MatchString(value) {
+			return ReasonToken
+		}
+	}
+
+	upperName := strings.ToUpper(name)
+	for _, keyword := range secretNameKeywords {
+		if strings.Contains(upperName, keyword) {
+			return ReasonSecretName
+		}
+	}
+
+	if looksRandom(value) {
+		return ReasonHighEntropy
+	}
+	return ""
+}
+
+func isSecretReference(value string) bool {
+	for _, prefix := range referencePrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksRandom reports whether the value is a long, high-entropy string such as a generated key.
+// Values with spaces, URLs and paths are excluded because they are rarely secrets by themselves.
+func looksRandom(value string) bool {
+	if len(value) < highEntropyMinLength {
+		return false
+	}
+	if strings.ContainsAny(value, " \t\n") || strings.Contains(value, "://") || strings.HasPrefix(value, "/") {
+		return false
+	}
+	return shannonEntropy(value) >= highEntropyThreshold
+}
+
+func shannonEntropy(value string) float64 {
+	counts := make(map[rune]int)
+	length := 0
+	for _, r := range value {
+		counts[r]++
+		length++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
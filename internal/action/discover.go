@@ -0,0 +1,145 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-to-k/lamver/pkg/client"
+	"github.com/go-to-k/lamver/pkg/runtimeversion"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+type ListFunctionsByRegionInput struct {
+	Ctx           context.Context
+	TargetRegions []string
+	// Concurrency is the number of regions listed at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	Lambda      client.LambdaClient
+}
+
+// ListFunctionsByRegion lists the functions in each target region. The result can be passed to
+// CreateFunctionListInput.Listings, not to list the functions again.
+func ListFunctionsByRegion(input *ListFunctionsByRegionInput) (map[string][]lambdaTypes.FunctionConfiguration, error) {
+	listings := make(map[string][]lambdaTypes.FunctionConfiguration, len(input.TargetRegions))
+	mu := sync.Mutex{}
+
+	err := forEachRegion(input.Ctx, input.Concurrency, input.TargetRegions, func(ctx context.Context, region string) error {
+		functions, err := input.Lambda.ListFunctionsWithRegion(ctx, region)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		listings[region] = functions
+		return nil
+	})
+	return listings, err
+}
+
+// DefaultRegionScanTimeout is how long a region is listed by ScanRegions at most.
+const DefaultRegionScanTimeout = 30 * time.Second
+
+type ScanRegionsInput struct {
+	Ctx           context.Context
+	TargetRegions []string
+	// Timeout is how long each region is listed at most. Defaults to DefaultRegionScanTimeout.
+	Timeout time.Duration
+	// Concurrency is the number of regions listed at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	Lambda      client.LambdaClient
+}
+
+// RegionScan is the result of listing the functions in a region by ScanRegions.
+type RegionScan struct {
+	Functions []lambdaTypes.FunctionConfiguration
+	Elapsed   time.Duration
+	// Err is the error of the listing. Functions are empty if it is not nil.
+	Err      error
+	TimedOut bool
+}
+
+// ScanRegions lists the functions in each target region with a timeout. Unlike ListFunctionsByRegion,
+// a failure in a region does not stop the others, and is set to the result of the region.
+func ScanRegions(input *ScanRegionsInput) map[string]RegionScan {
+	timeout := input.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRegionScanTimeout
+	}
+
+	scans := make(map[string]RegionScan, len(input.TargetRegions))
+	mu := sync.Mutex{}
+
+	// the listing never returns an error, so that a failure in a region does not cancel the others
+	err := forEachRegion(input.Ctx, input.Concurrency, input.TargetRegions, func(ctx context.Context, region string) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		start := time.Now()
+		functions, err := input.Lambda.ListFunctionsWithRegion(ctx, region)
+		scan := RegionScan{
+			Functions: functions,
+			Elapsed:   time.Since(start),
+			Err:       err,
+		}
+		if err != nil {
+			scan.Functions = []lambdaTypes.FunctionConfiguration{}
+			scan.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		scans[region] = scan
+		return nil
+	})
+	// regions not listed because the context is canceled
+	for _, region := range input.TargetRegions {
+		if _, ok := scans[region]; !ok && err != nil {
+			scans[region] = RegionScan{Err: err, Functions: []lambdaTypes.FunctionConfiguration{}}
+		}
+	}
+	return scans
+}
+
+// CountFunctionsByRuntime returns the number of the listed functions by runtime value.
+// Functions deployed as container images have no runtime value and are not counted.
+func CountFunctionsByRuntime(listings map[string][]lambdaTypes.FunctionConfiguration) map[string]int {
+	counts := make(map[string]int)
+	for _, functions := range listings {
+		for _, function := range functions {
+			if function.Runtime == "" {
+				continue
+			}
+			counts[string(function.Runtime)]++
+		}
+	}
+	return counts
+}
+
+// DiscoverRuntimes returns the runtime values of the listed functions, sorted by the name and the version.
+// Functions deployed as container images have no runtime value and are skipped.
+func DiscoverRuntimes(listings map[string][]lambdaTypes.FunctionConfiguration) []string {
+	seen := make(map[string]struct{})
+	runtimes := []string{}
+	for _, functions := range listings {
+		for _, function := range functions {
+			runtime := string(function.Runtime)
+			if runtime == "" {
+				continue
+			}
+			if _, ok := seen[runtime]; ok {
+				continue
+			}
+			seen[runtime] = struct{}{}
+			runtimes = append(runtimes, runtime)
+		}
+	}
+	sort.Slice(runtimes, func(i, j int) bool {
+		return runtimeversion.Less(runtimes[i], runtimes[j])
+	})
+	return runtimes
+}
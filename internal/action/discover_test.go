@@ -0,0 +1,168 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListFunctionsByRegion(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		targetRegions []string
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		want                      map[string][]lambdaTypes.FunctionConfiguration
+		wantErr                   bool
+	}{
+		{
+			name: "ListFunctionsByRegion success",
+			args: args{
+				ctx:           context.Background(),
+				targetRegions: []string{"us-east-1", "ap-northeast-1"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{FunctionName: aws.String("Function1"), Runtime: lambdaTypes.RuntimeNodejs20x},
+					}, nil,
+				)
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "ap-northeast-1").Return(
+					[]lambdaTypes.FunctionConfiguration{}, nil,
+				)
+			},
+			want: map[string][]lambdaTypes.FunctionConfiguration{
+				"us-east-1": {
+					{FunctionName: aws.String("Function1"), Runtime: lambdaTypes.RuntimeNodejs20x},
+				},
+				"ap-northeast-1": {},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListFunctionsByRegion fail by ListFunctionsWithRegion Error",
+			args: args{
+				ctx:           context.Background(),
+				targetRegions: []string{"us-east-1"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{}, fmt.Errorf("ListFunctionsError"),
+				)
+			},
+			want:    map[string][]lambdaTypes.FunctionConfiguration{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			got, err := ListFunctionsByRegion(&ListFunctionsByRegionInput{
+				Ctx:           tt.args.ctx,
+				TargetRegions: tt.args.targetRegions,
+				Lambda:        lambdaClientMock,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListFunctionsByRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListFunctionsByRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverRuntimes(t *testing.T) {
+	listings := map[string][]lambdaTypes.FunctionConfiguration{
+		"us-east-1": {
+			{FunctionName: aws.String("Function1"), Runtime: lambdaTypes.RuntimePython310},
+			{FunctionName: aws.String("Function2"), Runtime: lambdaTypes.Runtime("nodejs26.x")},
+			{FunctionName: aws.String("Image"), PackageType: lambdaTypes.PackageTypeImage},
+		},
+		"ap-northeast-1": {
+			{FunctionName: aws.String("Function3"), Runtime: lambdaTypes.RuntimePython39},
+			{FunctionName: aws.String("Function4"), Runtime: lambdaTypes.RuntimePython310},
+		},
+	}
+
+	want := []string{"nodejs26.x", "python3.9", "python3.10"}
+	if got := DiscoverRuntimes(listings); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiscoverRuntimes() = %v, want %v", got, want)
+	}
+}
+
+func TestScanRegions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+	lambdaClientMock.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+		[]lambdaTypes.FunctionConfiguration{
+			{FunctionName: aws.String("Function1"), Runtime: lambdaTypes.RuntimeNodejs20x},
+			{FunctionName: aws.String("Function2"), Runtime: lambdaTypes.RuntimePython312},
+		}, nil,
+	)
+	lambdaClientMock.EXPECT().ListFunctionsWithRegion(gomock.Any(), "ap-northeast-1").Return(
+		nil, fmt.Errorf("ListFunctionsError"),
+	)
+	lambdaClientMock.EXPECT().ListFunctionsWithRegion(gomock.Any(), "eu-west-1").DoAndReturn(
+		func(ctx context.Context, region string) ([]lambdaTypes.FunctionConfiguration, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	)
+
+	scans := ScanRegions(&ScanRegionsInput{
+		Ctx:           context.Background(),
+		TargetRegions: []string{"us-east-1", "ap-northeast-1", "eu-west-1"},
+		Timeout:       10 * time.Millisecond,
+		Lambda:        lambdaClientMock,
+	})
+
+	if len(scans) != 3 {
+		t.Fatalf("ScanRegions() returned %d regions, want 3", len(scans))
+	}
+	if scan := scans["us-east-1"]; scan.Err != nil || scan.TimedOut || len(scan.Functions) != 2 {
+		t.Errorf("ScanRegions() us-east-1 = %+v, want 2 functions", scan)
+	}
+	if scan := scans["ap-northeast-1"]; scan.Err == nil || scan.TimedOut || len(scan.Functions) != 0 {
+		t.Errorf("ScanRegions() ap-northeast-1 = %+v, want a failure", scan)
+	}
+	if scan := scans["eu-west-1"]; scan.Err == nil || !scan.TimedOut || len(scan.Functions) != 0 {
+		t.Errorf("ScanRegions() eu-west-1 = %+v, want a timeout", scan)
+	}
+}
+
+func TestCountFunctionsByRuntime(t *testing.T) {
+	listings := map[string][]lambdaTypes.FunctionConfiguration{
+		"us-east-1": {
+			{FunctionName: aws.String("Function1"), Runtime: lambdaTypes.RuntimePython310},
+			{FunctionName: aws.String("Function2"), Runtime: lambdaTypes.RuntimeNodejs20x},
+			{FunctionName: aws.String("Image"), PackageType: lambdaTypes.PackageTypeImage},
+		},
+		"ap-northeast-1": {
+			{FunctionName: aws.String("Function3"), Runtime: lambdaTypes.RuntimePython310},
+		},
+	}
+
+	want := map[string]int{"python3.10": 2, "nodejs20.x": 1}
+	if got := CountFunctionsByRuntime(listings); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountFunctionsByRuntime() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-to-k/lamver/internal/types"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+type EnrichInvocationsInput struct {
+	Ctx context.Context
+	// Functions are enriched in place.
+	Functions []types.LambdaFunctionData
+	// Period is how far back invocations are counted from EndTime.
+	Period time.Duration
+	// EndTime is the end of the period. Defaults to the current time.
+	EndTime time.Time
+	// Concurrency is the number of regions queried at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	CloudWatch  client.CloudWatchClient
+}
+
+// EnrichInvocations sets the invocations, errors and last invoked day of each function from CloudWatch metrics.
+func EnrichInvocations(input *EnrichInvocationsInput) error {
+	endTime := input.EndTime
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	startTime := endTime.Add(-input.Period)
+
+	indexesByRegion := groupFunctionIndexesByRegion(input.Functions)
+
+	return forEachRegion(input.Ctx, input.Concurrency, regionsOf(indexesByRegion), func(ctx context.Context, region string) error {
+		indexes := indexesByRegion[region]
+		functionNames := make([]string, 0, len(indexes))
+		for _, i := range indexes {
+			functionNames = append(functionNames, input.Functions[i].FunctionName)
+		}
+
+		metrics, err := input.CloudWatch.GetFunctionMetricsWithRegion(ctx, region, functionNames, startTime, endTime)
+		if err != nil {
+			return err
+		}
+
+		// each goroutine writes only the functions in its own region
+		for _, i := range indexes {
+			m := metrics[input.Functions[i].FunctionName]
+			input.Functions[i].Invocations = m.Invocations
+			input.Functions[i].Errors = m.Errors
+			input.Functions[i].LastInvoked = m.LastInvoked
+		}
+		return nil
+	})
+}
+
+type EnrichTriggersInput struct {
+	Ctx context.Context
+	// Functions are enriched in place.
+	Functions []types.LambdaFunctionData
+	// Concurrency is the number of regions queried at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	Lambda      client.LambdaClient
+}
+
+// EnrichTriggers sets the triggers of each function from the event source mappings in its region
+// and the principals in its resource-based policy.
+func EnrichTriggers(input *EnrichTriggersInput) error {
+	indexesByRegion := groupFunctionIndexesByRegion(input.Functions)
+
+	return forEachRegion(input.Ctx, input.Concurrency, regionsOf(indexesByRegion), func(ctx context.Context, region string) error {
+		indexes := indexesByRegion[region]
+		// event source mappings are listed once per region and joined to the functions
+		mappings, err := input.Lambda.ListEventSourceMappingsWithRegion(ctx, region)
+		if err != nil {
+			return err
+		}
+		triggersByArn := make(map[string][]string)
+		for _, mapping := range mappings {
+			functionArn := unqualifyFunctionArn(aws.ToString(mapping.FunctionArn))
+			triggersByArn[functionArn] = append(triggersByArn[functionArn], summarizeEventSourceMapping(mapping))
+		}
+
+		// each goroutine writes only the functions in its own region
+		for _, i := range indexes {
+			policy, err := input.Lambda.GetPolicyWithRegion(ctx, region, input.Functions[i].FunctionArn)
+			if err != nil {
+				return err
+			}
+			policyTriggers, err := summarizePolicyTriggers(policy)
+			if err != nil {
+				return fmt.Errorf("%s: %w", input.Functions[i].FunctionName, err)
+			}
+			triggers := append(triggersByArn[input.Functions[i].FunctionArn], policyTriggers...)
+			input.Functions[i].Triggers = uniqueSortedTriggers(triggers)
+		}
+		return nil
+	})
+}
+
+type EnrichFunctionURLsInput struct {
+	Ctx context.Context
+	// Functions are enriched in place.
+	Functions []types.LambdaFunctionData
+	// Concurrency is the number of regions queried at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	Lambda      client.LambdaClient
+}
+
+// EnrichFunctionURLs sets the auth type of the function URLs of each function, including those of its aliases.
+func EnrichFunctionURLs(input *EnrichFunctionURLsInput) error {
+	indexesByRegion := groupFunctionIndexesByRegion(input.Functions)
+
+	return forEachRegion(input.Ctx, input.Concurrency, regionsOf(indexesByRegion), func(ctx context.Context, region string) error {
+		indexes := indexesByRegion[region]
+		// each goroutine writes only the functions in its own region
+		for _, i := range indexes {
+			urlConfigs, err := input.Lambda.ListFunctionUrlConfigsWithRegion(ctx, region, input.Functions[i].FunctionArn)
+			if err != nil {
+				return err
+			}
+			input.Functions[i].FunctionURLAuthType = getMostPermissiveAuthType(urlConfigs)
+		}
+		return nil
+	})
+}
+
+func getMostPermissiveAuthType(urlConfigs []lambdaTypes.FunctionUrlConfig) string {
+	authType := ""
+	for _, urlConfig := range urlConfigs {
+		if urlConfig.AuthType == lambdaTypes.FunctionUrlAuthTypeNone {
+			return string(lambdaTypes.FunctionUrlAuthTypeNone)
+		}
+		authType = string(urlConfig.AuthType)
+	}
+	return authType
+}
+
+// FilterPublicFunctions returns the functions with a function URL that needs no auth.
+func FilterPublicFunctions(functions []types.LambdaFunctionData) []types.LambdaFunctionData {
+	publicFunctions := []types.LambdaFunctionData{}
+	for _, f := range functions {
+		if f.FunctionURLAuthType == string(lambdaTypes.FunctionUrlAuthTypeNone) {
+			publicFunctions = append(publicFunctions, f)
+		}
+	}
+	return publicFunctions
+}
+
+type EnrichCodeSigningConfigsInput struct {
+	Ctx context.Context
+	// Functions are enriched in place.
+	Functions []types.LambdaFunctionData
+	// Concurrency is the number of regions queried at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	Lambda      client.LambdaClient
+}
+
+// EnrichCodeSigningConfigs sets the code signing config of each function.
+func EnrichCodeSigningConfigs(input *EnrichCodeSigningConfigsInput) error {
+	indexesByRegion := groupFunctionIndexesByRegion(input.Functions)
+
+	return forEachRegion(input.Ctx, input.Concurrency, regionsOf(indexesByRegion), func(ctx context.Context, region string) error {
+		indexes := indexesByRegion[region]
+		// each goroutine writes only the functions in its own region
+		for _, i := range indexes {
+			codeSigningConfigArn, err := input.Lambda.GetFunctionCodeSigningConfigWithRegion(ctx, region, input.Functions[i].FunctionArn)
+			if err != nil {
+				return err
+			}
+			input.Functions[i].CodeSigningConfigArn = codeSigningConfigArn
+		}
+		return nil
+	})
+}
@@ -0,0 +1,501 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-to-k/lamver/internal/types"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnrichInvocations(t *testing.T) {
+	endTime := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	period := 30 * 24 * time.Hour
+	startTime := endTime.Add(-period)
+
+	type args struct {
+		ctx       context.Context
+		functions []types.LambdaFunctionData
+	}
+
+	tests := []struct {
+		name                          string
+		args                          args
+		prepareMockCloudWatchClientFn func(m *client.MockCloudWatchClient)
+		want                          []types.LambdaFunctionData
+		wantErr                       bool
+	}{
+		{
+			name: "EnrichInvocations success",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "ap-northeast-1", FunctionName: "Function1"},
+					{Region: "us-east-1", FunctionName: "Function2"},
+					{Region: "ap-northeast-1", FunctionName: "Function3"},
+				},
+			},
+			prepareMockCloudWatchClientFn: func(m *client.MockCloudWatchClient) {
+				m.EXPECT().GetFunctionMetricsWithRegion(
+					gomock.Any(), "ap-northeast-1", []string{"Function1", "Function3"}, startTime, endTime,
+				).Return(
+					map[string]client.FunctionMetrics{
+						"Function1": {
+							Invocations: 10,
+							Errors:      1,
+							LastInvoked: time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC),
+						},
+						"Function3": {},
+					}, nil,
+				)
+				m.EXPECT().GetFunctionMetricsWithRegion(
+					gomock.Any(), "us-east-1", []string{"Function2"}, startTime, endTime,
+				).Return(
+					map[string]client.FunctionMetrics{
+						"Function2": {
+							Invocations: 3,
+							LastInvoked: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						},
+					}, nil,
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{
+					Region:       "ap-northeast-1",
+					FunctionName: "Function1",
+					Invocations:  10,
+					Errors:       1,
+					LastInvoked:  time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Region:       "us-east-1",
+					FunctionName: "Function2",
+					Invocations:  3,
+					LastInvoked:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				},
+				{Region: "ap-northeast-1", FunctionName: "Function3"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "EnrichInvocations with no functions success",
+			args: args{
+				ctx:       context.Background(),
+				functions: []types.LambdaFunctionData{},
+			},
+			prepareMockCloudWatchClientFn: func(m *client.MockCloudWatchClient) {},
+			want:                          []types.LambdaFunctionData{},
+			wantErr:                       false,
+		},
+		{
+			name: "EnrichInvocations fail by GetFunctionMetricsWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "Function1"},
+				},
+			},
+			prepareMockCloudWatchClientFn: func(m *client.MockCloudWatchClient) {
+				m.EXPECT().GetFunctionMetricsWithRegion(
+					gomock.Any(), "us-east-1", []string{"Function1"}, startTime, endTime,
+				).Return(
+					map[string]client.FunctionMetrics{}, fmt.Errorf("GetMetricDataError"),
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{Region: "us-east-1", FunctionName: "Function1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudWatchClientMock := client.NewMockCloudWatchClient(ctrl)
+
+			tt.prepareMockCloudWatchClientFn(cloudWatchClientMock)
+
+			input := &EnrichInvocationsInput{
+				Ctx:        tt.args.ctx,
+				Functions:  tt.args.functions,
+				Period:     period,
+				EndTime:    endTime,
+				CloudWatch: cloudWatchClientMock,
+			}
+
+			err := EnrichInvocations(input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnrichInvocations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(tt.args.functions, tt.want) {
+				t.Errorf("EnrichInvocations() = %v, want %v", tt.args.functions, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichTriggers(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		functions []types.LambdaFunctionData
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		want                      []types.LambdaFunctionData
+		wantErr                   bool
+	}{
+		{
+			name: "EnrichTriggers success",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{
+						Region:       "us-east-1",
+						FunctionName: "Function1",
+						FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+					},
+					{
+						Region:       "us-east-1",
+						FunctionName: "Function2",
+						FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function2",
+					},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListEventSourceMappingsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.EventSourceMappingConfiguration{
+						{
+							EventSourceArn: aws.String("arn:aws:sqs:us-east-1:123456789012:Queue1"),
+							FunctionArn:    aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function1:live"),
+						},
+						{
+							EventSourceArn: aws.String("arn:aws:sqs:us-east-1:123456789012:Queue1"),
+							FunctionArn:    aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function1"),
+						},
+						{
+							EventSourceArn: aws.String("arn:aws:sqs:us-east-1:123456789012:Queue3"),
+							FunctionArn:    aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function3"),
+						},
+					}, nil,
+				)
+				m.EXPECT().GetPolicyWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function1").Return(
+					`{"Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Condition":{"ArnLike":{"AWS:SourceArn":"arn:aws:sns:us-east-1:123456789012:Topic1"}}}]}`, nil,
+				)
+				m.EXPECT().GetPolicyWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function2").Return(
+					"", nil,
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{
+					Region:       "us-east-1",
+					FunctionName: "Function1",
+					FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+					Triggers:     []string{"sns:Topic1", "sqs:Queue1"},
+				},
+				{
+					Region:       "us-east-1",
+					FunctionName: "Function2",
+					FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function2",
+					Triggers:     []string{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "EnrichTriggers fail by ListEventSourceMappingsWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{
+						Region:       "us-east-1",
+						FunctionName: "Function1",
+						FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+					},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListEventSourceMappingsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.EventSourceMappingConfiguration{}, fmt.Errorf("ListEventSourceMappingsError"),
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{
+					Region:       "us-east-1",
+					FunctionName: "Function1",
+					FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "EnrichTriggers fail by GetPolicyWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{
+						Region:       "us-east-1",
+						FunctionName: "Function1",
+						FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+					},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListEventSourceMappingsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.EventSourceMappingConfiguration{}, nil,
+				)
+				m.EXPECT().GetPolicyWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function1").Return(
+					"", fmt.Errorf("GetPolicyError"),
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{
+					Region:       "us-east-1",
+					FunctionName: "Function1",
+					FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			input := &EnrichTriggersInput{
+				Ctx:       tt.args.ctx,
+				Functions: tt.args.functions,
+				Lambda:    lambdaClientMock,
+			}
+
+			err := EnrichTriggers(input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnrichTriggers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(tt.args.functions, tt.want) {
+				t.Errorf("EnrichTriggers() = %v, want %v", tt.args.functions, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichFunctionURLs(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		functions []types.LambdaFunctionData
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		want                      []types.LambdaFunctionData
+		wantErr                   bool
+	}{
+		{
+			name: "EnrichFunctionURLs success",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "Function1", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1"},
+					{Region: "us-east-1", FunctionName: "Function2", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function2"},
+					{Region: "ap-northeast-1", FunctionName: "Function3", FunctionArn: "arn:aws:lambda:ap-northeast-1:123456789012:function:Function3"},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionUrlConfigsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function1").Return(
+					[]lambdaTypes.FunctionUrlConfig{
+						{AuthType: lambdaTypes.FunctionUrlAuthTypeAwsIam},
+						{AuthType: lambdaTypes.FunctionUrlAuthTypeNone},
+					}, nil,
+				)
+				m.EXPECT().ListFunctionUrlConfigsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function2").Return(
+					[]lambdaTypes.FunctionUrlConfig{
+						{AuthType: lambdaTypes.FunctionUrlAuthTypeAwsIam},
+					}, nil,
+				)
+				m.EXPECT().ListFunctionUrlConfigsWithRegion(gomock.Any(), "ap-northeast-1", "arn:aws:lambda:ap-northeast-1:123456789012:function:Function3").Return(
+					[]lambdaTypes.FunctionUrlConfig{}, nil,
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{Region: "us-east-1", FunctionName: "Function1", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1", FunctionURLAuthType: "NONE"},
+				{Region: "us-east-1", FunctionName: "Function2", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function2", FunctionURLAuthType: "AWS_IAM"},
+				{Region: "ap-northeast-1", FunctionName: "Function3", FunctionArn: "arn:aws:lambda:ap-northeast-1:123456789012:function:Function3"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "EnrichFunctionURLs fail by ListFunctionUrlConfigsWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "Function1", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1"},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionUrlConfigsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function1").Return(
+					[]lambdaTypes.FunctionUrlConfig{}, fmt.Errorf("ListFunctionUrlConfigsError"),
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{Region: "us-east-1", FunctionName: "Function1", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			input := &EnrichFunctionURLsInput{
+				Ctx:       tt.args.ctx,
+				Functions: tt.args.functions,
+				Lambda:    lambdaClientMock,
+			}
+
+			err := EnrichFunctionURLs(input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnrichFunctionURLs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(tt.args.functions, tt.want) {
+				t.Errorf("EnrichFunctionURLs() = %v, want %v", tt.args.functions, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterPublicFunctions(t *testing.T) {
+	tests := []struct {
+		name      string
+		functions []types.LambdaFunctionData
+		want      []types.LambdaFunctionData
+	}{
+		{
+			name: "FilterPublicFunctions keeps functions with auth type NONE",
+			functions: []types.LambdaFunctionData{
+				{FunctionName: "Function1", FunctionURLAuthType: "NONE"},
+				{FunctionName: "Function2", FunctionURLAuthType: "AWS_IAM"},
+				{FunctionName: "Function3"},
+			},
+			want: []types.LambdaFunctionData{
+				{FunctionName: "Function1", FunctionURLAuthType: "NONE"},
+			},
+		},
+		{
+			name:      "FilterPublicFunctions with no functions",
+			functions: []types.LambdaFunctionData{},
+			want:      []types.LambdaFunctionData{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FilterPublicFunctions(tt.functions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterPublicFunctions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichCodeSigningConfigs(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		functions []types.LambdaFunctionData
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		want                      []types.LambdaFunctionData
+		wantErr                   bool
+	}{
+		{
+			name: "EnrichCodeSigningConfigs success",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "Function1", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1"},
+					{Region: "us-east-1", FunctionName: "Function2", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function2"},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().GetFunctionCodeSigningConfigWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function1").Return(
+					"arn:aws:lambda:us-east-1:123456789012:code-signing-config:csc-0123456789abcdef0", nil,
+				)
+				m.EXPECT().GetFunctionCodeSigningConfigWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function2").Return(
+					"", nil,
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{
+					Region:               "us-east-1",
+					FunctionName:         "Function1",
+					FunctionArn:          "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+					CodeSigningConfigArn: "arn:aws:lambda:us-east-1:123456789012:code-signing-config:csc-0123456789abcdef0",
+				},
+				{Region: "us-east-1", FunctionName: "Function2", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "EnrichCodeSigningConfigs fail by GetFunctionCodeSigningConfigWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "Function1", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1"},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().GetFunctionCodeSigningConfigWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:Function1").Return(
+					"", fmt.Errorf("GetFunctionCodeSigningConfigError"),
+				)
+			},
+			want: []types.LambdaFunctionData{
+				{Region: "us-east-1", FunctionName: "Function1", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			input := &EnrichCodeSigningConfigsInput{
+				Ctx:       tt.args.ctx,
+				Functions: tt.args.functions,
+				Lambda:    lambdaClientMock,
+			}
+
+			err := EnrichCodeSigningConfigs(input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnrichCodeSigningConfigs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(tt.args.functions, tt.want) {
+				t.Errorf("EnrichCodeSigningConfigs() = %v, want %v", tt.args.functions, tt.want)
+			}
+		})
+	}
+}
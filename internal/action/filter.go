@@ -0,0 +1,125 @@
+package action
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// functionFilter holds the conditions that functions are filtered by in each region.
+type functionFilter struct {
+	targetRuntime  []string
+	keyword        string
+	tags           map[string]string
+	modifiedBefore time.Time
+	modifiedAfter  time.Time
+	vpc            VPCAttachment
+	architecture   string
+	query          *Query
+	// audit is not a condition, but audits the environment variables of the functions found in each region.
+	audit bool
+}
+
+// VPCAttachment filters functions by whether they are attached to a VPC.
+type VPCAttachment int
+
+const (
+	VPCAttachmentAny VPCAttachment = iota
+	VPCAttachmentAttached
+	VPCAttachmentDetached
+)
+
+// matchVPC reports whether the VPC attachment of a function with the VPC ID matches the filter.
+func (f *functionFilter) matchVPC(vpcID string) bool {
+	switch f.vpc {
+	case VPCAttachmentAttached:
+		return vpcID != ""
+	case VPCAttachmentDetached:
+		return vpcID == ""
+	default:
+		return true
+	}
+}
+
+// matchLastModified reports whether lastModified is before modifiedBefore (exclusive)
+// and not before modifiedAfter (inclusive). Zero values mean no limit.
+func (f *functionFilter) matchLastModified(lastModified time.Time) bool {
+	if f.modifiedBefore.IsZero() && f.modifiedAfter.IsZero() {
+		return true
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	if !f.modifiedBefore.IsZero() && !lastModified.Before(f.modifiedBefore) {
+		return false
+	}
+	if !f.modifiedAfter.IsZero() && lastModified.Before(f.modifiedAfter) {
+		return false
+	}
+	return true
+}
+
+// matchArchitecture reports whether the architectures of a function include the architecture of the filter.
+func (f *functionFilter) matchArchitecture(architectures []lambdaTypes.Architecture) bool {
+	if f.architecture == "" {
+		return true
+	}
+	if len(architectures) == 0 {
+		return f.architecture == string(lambdaTypes.ArchitectureX8664)
+	}
+	for _, architecture := range architectures {
+		if string(architecture) == f.architecture {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateArchitecture validates the architecture to filter functions by.
+func ValidateArchitecture(architecture string) error {
+	for _, a := range lambdaTypes.ArchitectureX8664.Values() {
+		if string(a) == architecture {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported architecture: %s (%s|%s)", architecture, lambdaTypes.ArchitectureX8664, lambdaTypes.ArchitectureArm64)
+}
+
+// ParseDate parses a date such as "2024-01-01" (in UTC) or an RFC 3339 timestamp.
+func ParseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %q, must be YYYY-MM-DD or RFC 3339 format", s)
+}
+
+// ParseAge parses an age such as "365d", "4w" or a Go duration such as "36h".
+func ParseAge(s string) (time.Duration, error) {
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+
+	if unit != 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age: %q, must be such as 365d, 4w or 36h", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age: %q, must be such as 365d, 4w or 36h", s)
+	}
+	return d, nil
+}
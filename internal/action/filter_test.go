@@ -0,0 +1,236 @@
+package action
+
+import (
+	"testing"
+	"time"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "ParseDate success with date",
+			s:       "2024-01-01",
+			want:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "ParseDate success with RFC 3339",
+			s:       "2024-01-01T09:00:00+09:00",
+			want:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "ParseDate fail with invalid date",
+			s:       "2024/01/01",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "ParseAge success with days",
+			s:       "365d",
+			want:    365 * 24 * time.Hour,
+			wantErr: false,
+		},
+		{
+			name:    "ParseAge success with weeks",
+			s:       "2w",
+			want:    14 * 24 * time.Hour,
+			wantErr: false,
+		},
+		{
+			name:    "ParseAge success with Go duration",
+			s:       "36h",
+			want:    36 * time.Hour,
+			wantErr: false,
+		},
+		{
+			name:    "ParseAge fail with invalid days",
+			s:       "xd",
+			wantErr: true,
+		},
+		{
+			name:    "ParseAge fail with negative age",
+			s:       "-1d",
+			wantErr: true,
+		},
+		{
+			name:    "ParseAge fail with unknown unit",
+			s:       "1y",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAge(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_functionFilter_matchLastModified(t *testing.T) {
+	before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		filter       *functionFilter
+		lastModified time.Time
+		want         bool
+	}{
+		{
+			name:         "matchLastModified with no limit",
+			filter:       &functionFilter{},
+			lastModified: time.Time{},
+			want:         true,
+		},
+		{
+			name:         "matchLastModified before modifiedBefore",
+			filter:       &functionFilter{modifiedBefore: before},
+			lastModified: before.Add(-time.Millisecond),
+			want:         true,
+		},
+		{
+			name:         "matchLastModified at modifiedBefore is excluded",
+			filter:       &functionFilter{modifiedBefore: before},
+			lastModified: before,
+			want:         false,
+		},
+		{
+			name:         "matchLastModified at modifiedAfter is included",
+			filter:       &functionFilter{modifiedAfter: after},
+			lastModified: after,
+			want:         true,
+		},
+		{
+			name:         "matchLastModified before modifiedAfter is excluded",
+			filter:       &functionFilter{modifiedAfter: after},
+			lastModified: after.Add(-time.Millisecond),
+			want:         false,
+		},
+		{
+			name:         "matchLastModified between modifiedAfter and modifiedBefore",
+			filter:       &functionFilter{modifiedBefore: before, modifiedAfter: after},
+			lastModified: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:         true,
+		},
+		{
+			name:         "matchLastModified with unknown last modified is excluded",
+			filter:       &functionFilter{modifiedBefore: before},
+			lastModified: time.Time{},
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matchLastModified(tt.lastModified); got != tt.want {
+				t.Errorf("functionFilter.matchLastModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_functionFilter_matchArchitecture(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        *functionFilter
+		architectures []lambdaTypes.Architecture
+		want          bool
+	}{
+		{
+			name:          "matchArchitecture with no architecture filter",
+			filter:        &functionFilter{},
+			architectures: []lambdaTypes.Architecture{lambdaTypes.ArchitectureArm64},
+			want:          true,
+		},
+		{
+			name:          "matchArchitecture with arm64",
+			filter:        &functionFilter{architecture: "arm64"},
+			architectures: []lambdaTypes.Architecture{lambdaTypes.ArchitectureArm64},
+			want:          true,
+		},
+		{
+			name:          "matchArchitecture with arm64 for x86_64 function",
+			filter:        &functionFilter{architecture: "arm64"},
+			architectures: []lambdaTypes.Architecture{lambdaTypes.ArchitectureX8664},
+			want:          false,
+		},
+		{
+			name:          "matchArchitecture with x86_64 for function without architectures",
+			filter:        &functionFilter{architecture: "x86_64"},
+			architectures: nil,
+			want:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matchArchitecture(tt.architectures); got != tt.want {
+				t.Errorf("functionFilter.matchArchitecture() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateArchitecture(t *testing.T) {
+	tests := []struct {
+		name         string
+		architecture string
+		wantErr      bool
+	}{
+		{
+			name:         "ValidateArchitecture with x86_64",
+			architecture: "x86_64",
+			wantErr:      false,
+		},
+		{
+			name:         "ValidateArchitecture with arm64",
+			architecture: "arm64",
+			wantErr:      false,
+		},
+		{
+			name:         "ValidateArchitecture with unknown architecture",
+			architecture: "amd64",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateArchitecture(tt.architecture); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateArchitecture() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
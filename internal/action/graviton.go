@@ -0,0 +1,160 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-to-k/lamver/internal/runtimecatalog"
+	"github.com/go-to-k/lamver/internal/types"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/smithy-go"
+)
+
+type FindGravitonCandidatesInput struct {
+	Ctx       context.Context
+	Functions []types.LambdaFunctionData
+	// Catalog tells which runtimes support arm64. If nil, the default catalog is used.
+	Catalog *runtimecatalog.Catalog
+	// Concurrency is the number of regions queried at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	Lambda      client.LambdaClient
+}
+
+// FindGravitonCandidates returns the x86_64 functions that can be migrated to arm64 (Graviton),
+// i.e. whose runtime and all layers support arm64. Functions with layers whose compatible
+// architectures cannot be listed, such as layers shared from other accounts, are excluded.
+// So are functions whose runtime is not in the catalog.
+func FindGravitonCandidates(input *FindGravitonCandidatesInput) ([]types.LambdaFunctionData, error) {
+	catalog := input.Catalog
+	if catalog == nil {
+		defaultCatalog, err := runtimecatalog.Default()
+		if err != nil {
+			return []types.LambdaFunctionData{}, err
+		}
+		catalog = defaultCatalog
+	}
+
+	x86Functions := []types.LambdaFunctionData{}
+	for _, f := range input.Functions {
+		if isX86Only(f.Architectures) && catalog.SupportsArchitecture(string(f.Runtime), runtimecatalog.ArchitectureArm64) {
+			x86Functions = append(x86Functions, f)
+		}
+	}
+
+	indexesByRegion := groupFunctionIndexesByRegion(x86Functions)
+	// a candidate flag per function, written only by the goroutine of its region
+	candidates := make([]bool, len(x86Functions))
+
+	err := forEachRegion(input.Ctx, input.Concurrency, regionsOf(indexesByRegion), func(ctx context.Context, region string) error {
+		indexes := indexesByRegion[region]
+		layerArm64Support, err := getLayerArm64Support(ctx, region, x86Functions, indexes, input.Lambda)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range indexes {
+			candidates[i] = true
+			for _, layer := range x86Functions[i].Layers {
+				if !layerArm64Support[layer] {
+					candidates[i] = false
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return []types.LambdaFunctionData{}, err
+	}
+
+	gravitonCandidates := []types.LambdaFunctionData{}
+	for i, f := range x86Functions {
+		if candidates[i] {
+			gravitonCandidates = append(gravitonCandidates, f)
+		}
+	}
+	return gravitonCandidates, nil
+}
+
+// getLayerArm64Support returns whether each layer version used by the functions supports arm64.
+// Each layer is listed once, even if several functions or versions use it.
+func getLayerArm64Support(
+	ctx context.Context,
+	region string,
+	functions []types.LambdaFunctionData,
+	indexes []int,
+	lambda client.LambdaClient,
+) (map[string]bool, error) {
+	layerArm64Support := make(map[string]bool)
+	listedLayers := make(map[string]struct{})
+
+	for _, i := range indexes {
+		for _, layerVersionArn := range functions[i].Layers {
+			layerArn := unversionLayerArn(layerVersionArn)
+			if _, ok := listedLayers[layerArn]; ok {
+				continue
+			}
+			listedLayers[layerArn] = struct{}{}
+
+			versions, err := lambda.ListLayerVersionsWithRegion(ctx, region, layerArn)
+			if err != nil {
+				if isLayerNotAccessible(err) {
+					continue
+				}
+				return layerArm64Support, err
+			}
+			for _, version := range versions {
+				for _, architecture := range version.CompatibleArchitectures {
+					if architecture == lambdaTypes.ArchitectureArm64 {
+						layerArm64Support[*version.LayerVersionArn] = true
+					}
+				}
+			}
+		}
+	}
+
+	return layerArm64Support, nil
+}
+
+func isLayerNotAccessible(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.ErrorCode() {
+	case "AccessDeniedException", "ResourceNotFoundException":
+		return true
+	default:
+		return false
+	}
+}
+
+func isX86Only(architectures []string) bool {
+	for _, architecture := range architectures {
+		if architecture == string(lambdaTypes.ArchitectureArm64) {
+			return false
+		}
+	}
+	return true
+}
+
+// unversionLayerArn removes the version from the layer version ARN.
+func unversionLayerArn(layerVersionArn string) string {
+	parts := strings.Split(layerVersionArn, ":")
+	if len(parts) > 7 {
+		return strings.Join(parts[:7], ":")
+	}
+	return layerVersionArn
+}
+
+// CountFunctionsByRegion returns the number of functions in each region.
+func CountFunctionsByRegion(functions []types.LambdaFunctionData) map[string]int {
+	counts := make(map[string]int)
+	for _, f := range functions {
+		counts[f.Region]++
+	}
+	return counts
+}
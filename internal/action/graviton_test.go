@@ -0,0 +1,154 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-to-k/lamver/internal/types"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/smithy-go"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindGravitonCandidates(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		functions []types.LambdaFunctionData
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		want                      []string
+		wantErr                   bool
+	}{
+		{
+			name: "FindGravitonCandidates success",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Runtime: lambdaTypes.RuntimePython312, Region: "us-east-1", FunctionName: "NoLayer"},
+					{Runtime: lambdaTypes.RuntimePython312, Region: "us-east-1", FunctionName: "Arm64", Architectures: []string{"arm64"}},
+					{Runtime: lambdaTypes.RuntimeGo1x, Region: "us-east-1", FunctionName: "UnsupportedRuntime", Architectures: []string{"x86_64"}},
+					{Runtime: lambdaTypes.RuntimeNodejs810, Region: "us-east-1", FunctionName: "UnknownRuntime", Architectures: []string{"x86_64"}},
+					{
+						Runtime:      lambdaTypes.RuntimeNodejs20x,
+						Region:       "us-east-1",
+						FunctionName: "Arm64Layer",
+						Layers:       []string{"arn:aws:lambda:us-east-1:123456789012:layer:Layer1:2"},
+					},
+					{
+						Runtime:      lambdaTypes.RuntimeNodejs20x,
+						Region:       "us-east-1",
+						FunctionName: "X86Layer",
+						Layers: []string{
+							"arn:aws:lambda:us-east-1:123456789012:layer:Layer1:2",
+							"arn:aws:lambda:us-east-1:123456789012:layer:Layer2:1",
+						},
+					},
+					{
+						Runtime:      lambdaTypes.RuntimeNodejs20x,
+						Region:       "us-east-1",
+						FunctionName: "SharedLayer",
+						Layers:       []string{"arn:aws:lambda:us-east-1:210987654321:layer:Layer3:1"},
+					},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListLayerVersionsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:layer:Layer1").Return(
+					[]lambdaTypes.LayerVersionsListItem{
+						{
+							LayerVersionArn:         aws.String("arn:aws:lambda:us-east-1:123456789012:layer:Layer1:2"),
+							CompatibleArchitectures: []lambdaTypes.Architecture{lambdaTypes.ArchitectureX8664, lambdaTypes.ArchitectureArm64},
+						},
+						{
+							LayerVersionArn:         aws.String("arn:aws:lambda:us-east-1:123456789012:layer:Layer1:1"),
+							CompatibleArchitectures: []lambdaTypes.Architecture{lambdaTypes.ArchitectureX8664},
+						},
+					}, nil,
+				)
+				m.EXPECT().ListLayerVersionsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:layer:Layer2").Return(
+					[]lambdaTypes.LayerVersionsListItem{
+						{
+							LayerVersionArn: aws.String("arn:aws:lambda:us-east-1:123456789012:layer:Layer2:1"),
+						},
+					}, nil,
+				)
+				m.EXPECT().ListLayerVersionsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:210987654321:layer:Layer3").Return(
+					[]lambdaTypes.LayerVersionsListItem{}, &smithy.GenericAPIError{Code: "AccessDeniedException"},
+				)
+			},
+			want:    []string{"NoLayer", "Arm64Layer"},
+			wantErr: false,
+		},
+		{
+			name: "FindGravitonCandidates fail by ListLayerVersionsWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{
+						Runtime:      lambdaTypes.RuntimeNodejs20x,
+						Region:       "us-east-1",
+						FunctionName: "Function1",
+						Layers:       []string{"arn:aws:lambda:us-east-1:123456789012:layer:Layer1:2"},
+					},
+				},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListLayerVersionsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:layer:Layer1").Return(
+					[]lambdaTypes.LayerVersionsListItem{}, fmt.Errorf("ListLayerVersionsError"),
+				)
+			},
+			want:    []string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			input := &FindGravitonCandidatesInput{
+				Ctx:       tt.args.ctx,
+				Functions: tt.args.functions,
+				Lambda:    lambdaClientMock,
+			}
+
+			got, err := FindGravitonCandidates(input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindGravitonCandidates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			gotNames := []string{}
+			for _, f := range got {
+				gotNames = append(gotNames, f.FunctionName)
+			}
+			if !reflect.DeepEqual(gotNames, tt.want) {
+				t.Errorf("FindGravitonCandidates() = %v, want %v", gotNames, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountFunctionsByRegion(t *testing.T) {
+	functions := []types.LambdaFunctionData{
+		{Region: "us-east-1", FunctionName: "Function1"},
+		{Region: "ap-northeast-1", FunctionName: "Function2"},
+		{Region: "us-east-1", FunctionName: "Function3"},
+	}
+	want := map[string]int{
+		"us-east-1":      2,
+		"ap-northeast-1": 1,
+	}
+	if got := CountFunctionsByRegion(functions); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountFunctionsByRegion() = %v, want %v", got, want)
+	}
+}
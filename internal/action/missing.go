@@ -0,0 +1,91 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-to-k/lamver/internal/types"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Configurations that functions can be filtered by if they are missing.
+const (
+	MissingTracing     = "tracing"
+	MissingDLQ         = "dlq"
+	MissingSnapStart   = "snapstart"
+	MissingCodeSigning = "code-signing"
+)
+
+var missingConfigs = []string{
+	MissingTracing,
+	MissingDLQ,
+	MissingSnapStart,
+	MissingCodeSigning,
+}
+
+// ParseMissingConfigs parses comma-separated configurations such as "tracing,dlq" (case-insensitive).
+func ParseMissingConfigs(s string) ([]string, error) {
+	configs := []string{}
+	if s == "" {
+		return configs, nil
+	}
+
+	for _, config := range strings.Split(s, ",") {
+		config = strings.ToLower(strings.TrimSpace(config))
+		if config == "" {
+			return configs, fmt.Errorf("empty configuration in --missing: %q", s)
+		}
+		valid := false
+		for _, c := range missingConfigs {
+			if config == c {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return configs, fmt.Errorf("unknown configuration in --missing: %s (%s)", config, strings.Join(missingConfigs, "|"))
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// NeedsCodeSigningConfigs reports whether the configurations include code signing, which needs an API call per function.
+func NeedsCodeSigningConfigs(configs []string) bool {
+	for _, config := range configs {
+		if config == MissingCodeSigning {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterMissingConfigs returns the functions missing any of the configurations.
+func FilterMissingConfigs(functions []types.LambdaFunctionData, configs []string) []types.LambdaFunctionData {
+	missingFunctions := []types.LambdaFunctionData{}
+	for _, f := range functions {
+		for _, config := range configs {
+			if isMissingConfig(&f, config) {
+				missingFunctions = append(missingFunctions, f)
+				break
+			}
+		}
+	}
+	return missingFunctions
+}
+
+func isMissingConfig(f *types.LambdaFunctionData, config string) bool {
+	switch config {
+	case MissingTracing:
+		return f.TracingMode != string(lambdaTypes.TracingModeActive)
+	case MissingDLQ:
+		return f.DeadLetterTargetArn == ""
+	case MissingSnapStart:
+		return f.SnapStart != string(lambdaTypes.SnapStartApplyOnPublishedVersions)
+	case MissingCodeSigning:
+		return f.CodeSigningConfigArn == ""
+	default:
+		return false
+	}
+}
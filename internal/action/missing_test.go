@@ -0,0 +1,106 @@
+package action
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-to-k/lamver/internal/types"
+)
+
+func TestParseMissingConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "ParseMissingConfigs with empty string",
+			s:       "",
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "ParseMissingConfigs with multiple configurations",
+			s:       "tracing, DLQ,code-signing",
+			want:    []string{MissingTracing, MissingDLQ, MissingCodeSigning},
+			wantErr: false,
+		},
+		{
+			name:    "ParseMissingConfigs fail with unknown configuration",
+			s:       "tracing,vpc",
+			want:    []string{MissingTracing},
+			wantErr: true,
+		},
+		{
+			name:    "ParseMissingConfigs fail with empty configuration",
+			s:       "tracing,",
+			want:    []string{MissingTracing},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMissingConfigs(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMissingConfigs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMissingConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMissingConfigs(t *testing.T) {
+	functions := []types.LambdaFunctionData{
+		{
+			FunctionName:        "Compliant",
+			TracingMode:         "Active",
+			DeadLetterTargetArn: "arn:aws:sqs:us-east-1:123456789012:dlq",
+		},
+		{
+			FunctionName:        "NoTracing",
+			TracingMode:         "PassThrough",
+			DeadLetterTargetArn: "arn:aws:sqs:us-east-1:123456789012:dlq",
+		},
+		{
+			FunctionName: "NoDLQ",
+			TracingMode:  "Active",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		configs []string
+		want    []string
+	}{
+		{
+			name:    "FilterMissingConfigs with tracing",
+			configs: []string{MissingTracing},
+			want:    []string{"NoTracing"},
+		},
+		{
+			name:    "FilterMissingConfigs with tracing and dlq",
+			configs: []string{MissingTracing, MissingDLQ},
+			want:    []string{"NoTracing", "NoDLQ"},
+		},
+		{
+			name:    "FilterMissingConfigs with snapstart",
+			configs: []string{MissingSnapStart},
+			want:    []string{"Compliant", "NoTracing", "NoDLQ"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, f := range FilterMissingConfigs(functions, tt.configs) {
+				got = append(got, f.FunctionName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterMissingConfigs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
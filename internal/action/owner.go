@@ -0,0 +1,141 @@
+package action
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-to-k/lamver/internal/ownership"
+	"github.com/go-to-k/lamver/internal/types"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultTagConcurrency is the number of functions whose tags are listed at the same time in each region by default.
+const DefaultTagConcurrency = 10
+
+type AssignOwnersInput struct {
+	Ctx context.Context
+	// Functions are assigned in place.
+	Functions []types.LambdaFunctionData
+	// TagKey is the tag key of the owners, such as "team". If empty, tags are not listed.
+	TagKey string
+	// Mapping resolves the owners of functions without the tag by the name prefixes. It is optional.
+	Mapping *ownership.Mapping
+	// Concurrency is the number of regions queried at the same time. Defaults to the number of CPUs.
+	Concurrency int
+	// TagConcurrency is the number of functions whose tags are listed at the same time in each region.
+	// Defaults to DefaultTagConcurrency.
+	TagConcurrency int
+	Lambda         client.LambdaClient
+}
+
+// AssignOwners sets the owner of each function by the tag, then by the name prefix mapping.
+// Functions owned by neither are assigned to ownership.Unowned. The tags already listed for
+// the search are reused, and the others are listed and set to the functions.
+func AssignOwners(input *AssignOwnersInput) error {
+	if input.TagKey == "" {
+		for i := range input.Functions {
+			input.Functions[i].Owner = resolveOwner(&input.Functions[i], "", input.Mapping)
+		}
+		return nil
+	}
+
+	tagConcurrency := input.TagConcurrency
+	if tagConcurrency <= 0 {
+		tagConcurrency = DefaultTagConcurrency
+	}
+	indexesByRegion := groupFunctionIndexesByRegion(input.Functions)
+
+	return forEachRegion(input.Ctx, input.Concurrency, regionsOf(indexesByRegion), func(ctx context.Context, region string) error {
+		eg, ctx := errgroup.WithContext(ctx)
+		eg.SetLimit(tagConcurrency)
+
+		// each goroutine writes only its own function
+		for _, i := range indexesByRegion[region] {
+			f := &input.Functions[i]
+			if f.Tags != nil {
+				f.Owner = resolveOwner(f, f.Tags[input.TagKey], input.Mapping)
+				continue
+			}
+			eg.Go(func() error {
+				tags, err := input.Lambda.ListTagsWithRegion(ctx, region, f.FunctionArn)
+				if err != nil {
+					return err
+				}
+				if tags == nil {
+					tags = map[string]string{}
+				}
+				f.Tags = tags
+				f.Owner = resolveOwner(f, tags[input.TagKey], input.Mapping)
+				return nil
+			})
+		}
+		return eg.Wait()
+	})
+}
+
+func resolveOwner(f *types.LambdaFunctionData, tagValue string, mapping *ownership.Mapping) string {
+	if owner := strings.TrimSpace(tagValue); owner != "" {
+		return owner
+	}
+	if owner, ok := mapping.Owner(f.FunctionName); ok {
+		return owner
+	}
+	return ownership.Unowned
+}
+
+// IsRuntimeDeprecated reports whether the runtime of the function is deprecated at the time.
+func IsRuntimeDeprecated(f *types.LambdaFunctionData, now time.Time) bool {
+	return !f.RuntimeDeprecation.IsZero() && !f.RuntimeDeprecation.After(now)
+}
+
+// OwnerSummary is the number of functions of an owner, and of those on deprecated runtimes.
+type OwnerSummary struct {
+	Owner               string
+	Functions           int
+	DeprecatedFunctions int
+	// DeprecatedRuntimes is the number of functions by deprecated runtime value.
+	DeprecatedRuntimes map[string]int
+}
+
+// SummarizeOwners returns the summary of each owner of the functions, sorted by the owner with ownership.Unowned last.
+func SummarizeOwners(functions []types.LambdaFunctionData, now time.Time) []OwnerSummary {
+	summaries := make(map[string]*OwnerSummary)
+	for i := range functions {
+		f := &functions[i]
+		summary, ok := summaries[f.Owner]
+		if !ok {
+			summary = &OwnerSummary{Owner: f.Owner, DeprecatedRuntimes: map[string]int{}}
+			summaries[f.Owner] = summary
+		}
+		summary.Functions++
+		if IsRuntimeDeprecated(f, now) {
+			summary.DeprecatedFunctions++
+			summary.DeprecatedRuntimes[string(f.Runtime)]++
+		}
+	}
+
+	result := make([]OwnerSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Owner == ownership.Unowned) != (result[j].Owner == ownership.Unowned) {
+			return result[j].Owner == ownership.Unowned
+		}
+		return result[i].Owner < result[j].Owner
+	})
+	return result
+}
+
+// GroupFunctionsByOwner returns the functions by the owner, keeping the order.
+func GroupFunctionsByOwner(functions []types.LambdaFunctionData) map[string][]types.LambdaFunctionData {
+	functionsByOwner := make(map[string][]types.LambdaFunctionData)
+	for _, f := range functions {
+		functionsByOwner[f.Owner] = append(functionsByOwner[f.Owner], f)
+	}
+	return functionsByOwner
+}
@@ -0,0 +1,178 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-to-k/lamver/internal/ownership"
+	"github.com/go-to-k/lamver/internal/types"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssignOwners(t *testing.T) {
+	mapping := &ownership.Mapping{
+		Prefixes: map[string]string{"orders-": "team-orders"},
+	}
+
+	type args struct {
+		ctx       context.Context
+		functions []types.LambdaFunctionData
+		tagKey    string
+		mapping   *ownership.Mapping
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		want                      []string
+		wantErr                   bool
+	}{
+		{
+			name: "AssignOwners success by the tag, then the mapping",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "orders-api", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:orders-api"},
+					{Region: "us-east-1", FunctionName: "orders-worker", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:orders-worker"},
+					{Region: "ap-northeast-1", FunctionName: "batch", FunctionArn: "arn:aws:lambda:ap-northeast-1:123456789012:function:batch"},
+				},
+				tagKey:  "team",
+				mapping: mapping,
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:orders-api").Return(
+					map[string]string{"team": "team-api"}, nil,
+				)
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:orders-worker").Return(
+					map[string]string{"team": " "}, nil,
+				)
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "ap-northeast-1", "arn:aws:lambda:ap-northeast-1:123456789012:function:batch").Return(
+					map[string]string{"owner": "someone"}, nil,
+				)
+			},
+			want:    []string{"team-api", "team-orders", ownership.Unowned},
+			wantErr: false,
+		},
+		{
+			name: "AssignOwners success by the tags listed for the search without listing them again",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "orders-api", Tags: map[string]string{"team": "team-api"}},
+					{Region: "us-east-1", FunctionName: "orders-worker", Tags: map[string]string{}},
+				},
+				tagKey:  "team",
+				mapping: mapping,
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {},
+			want:                      []string{"team-api", "team-orders"},
+			wantErr:                   false,
+		},
+		{
+			name: "AssignOwners success by only the mapping without the tag key",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "orders-api"},
+					{Region: "us-east-1", FunctionName: "batch"},
+				},
+				mapping: mapping,
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {},
+			want:                      []string{"team-orders", ownership.Unowned},
+			wantErr:                   false,
+		},
+		{
+			name: "AssignOwners fail by ListTagsWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				functions: []types.LambdaFunctionData{
+					{Region: "us-east-1", FunctionName: "orders-api", FunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:orders-api"},
+				},
+				tagKey: "team",
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:orders-api").Return(
+					map[string]string{}, fmt.Errorf("ListTagsError"),
+				)
+			},
+			want:    []string{""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			input := &AssignOwnersInput{
+				Ctx:       tt.args.ctx,
+				Functions: tt.args.functions,
+				TagKey:    tt.args.tagKey,
+				Mapping:   tt.args.mapping,
+				Lambda:    lambdaClientMock,
+			}
+
+			err := AssignOwners(input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AssignOwners() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			got := make([]string, 0, len(input.Functions))
+			for _, f := range input.Functions {
+				got = append(got, f.Owner)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AssignOwners() owners = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeOwners(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	deprecated := time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)
+	upcoming := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	functions := []types.LambdaFunctionData{
+		{Owner: ownership.Unowned, FunctionName: "Function1", Runtime: "nodejs16.x", RuntimeDeprecation: deprecated},
+		{Owner: "team-b", FunctionName: "Function2", Runtime: "nodejs18.x", RuntimeDeprecation: upcoming},
+		{Owner: "team-a", FunctionName: "Function3", Runtime: "nodejs16.x", RuntimeDeprecation: deprecated},
+		{Owner: "team-a", FunctionName: "Function4", Runtime: "nodejs16.x", RuntimeDeprecation: deprecated},
+		{Owner: "team-a", FunctionName: "Function5", Runtime: "python3.13"},
+		{Owner: "team-a", FunctionName: "Function6", Runtime: "python3.8", RuntimeDeprecation: now},
+	}
+
+	want := []OwnerSummary{
+		{Owner: "team-a", Functions: 4, DeprecatedFunctions: 3, DeprecatedRuntimes: map[string]int{"nodejs16.x": 2, "python3.8": 1}},
+		{Owner: "team-b", Functions: 1, DeprecatedFunctions: 0, DeprecatedRuntimes: map[string]int{}},
+		{Owner: ownership.Unowned, Functions: 1, DeprecatedFunctions: 1, DeprecatedRuntimes: map[string]int{"nodejs16.x": 1}},
+	}
+	if got := SummarizeOwners(functions, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeOwners() = %v, want %v", got, want)
+	}
+
+	wantGroups := map[string][]string{
+		"team-a":          {"Function3", "Function4", "Function5", "Function6"},
+		"team-b":          {"Function2"},
+		ownership.Unowned: {"Function1"},
+	}
+	gotGroups := map[string][]string{}
+	for owner, group := range GroupFunctionsByOwner(functions) {
+		for _, f := range group {
+			gotGroups[owner] = append(gotGroups[owner], f.FunctionName)
+		}
+	}
+	if !reflect.DeepEqual(gotGroups, wantGroups) {
+		t.Errorf("GroupFunctionsByOwner() = %v, want %v", gotGroups, wantGroups)
+	}
+}
@@ -0,0 +1,332 @@
+package action
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+const (
+	QueryFieldName     = "name"
+	QueryFieldRuntime  = "runtime"
+	QueryFieldRegion   = "region"
+	QueryFieldTag      = "tag"
+	QueryFieldArch     = "arch"
+	QueryFieldModified = "modified"
+)
+
+var (
+	queryFields = []string{
+		QueryFieldName,
+		QueryFieldRuntime,
+		QueryFieldRegion,
+		QueryFieldTag,
+		QueryFieldArch,
+		QueryFieldModified,
+	}
+	// e.g. "name:api" or "modified<2023-06-01"
+	queryTermPattern = regexp.MustCompile(`^([a-zA-Z]+)(:|<=|>=|<|>)(.*)$`)
+)
+
+// Query is a parsed search query such as "name:api runtime:python3.* -name:test modified<2023-06-01".
+// A function matches the query if it matches all the terms.
+type Query struct {
+	terms []queryTerm
+}
+
+// queryTerm is a node of the query. It matches a function unless negated.
+type queryTerm struct {
+	negated bool
+	raw     string
+	match   func(target *queryTarget) bool
+	// needsTags is true if the term needs the tags of the function.
+	needsTags bool
+}
+
+// queryTarget is the function evaluated by the query.
+type queryTarget struct {
+	name          string
+	runtime       string
+	region        string
+	architectures []string
+	lastModified  time.Time
+	tags          map[string]string
+}
+
+// ParseQuery parses a query of space-separated terms, all of which must match:
+//
+//   - name:VALUE, runtime:VALUE, region:VALUE and arch:VALUE match the field by VALUE.
+//     VALUE can have wildcards (* and ?), and comma-separated alternatives such as "region:eu-*,us-east-1".
+//     A name without wildcards matches a part of the name, and the others match the whole value.
+//   - tag:KEY=VALUE matches functions with the tag, and tag:KEY matches functions with the tag key of any value.
+//   - modified<DATE, modified<=DATE, modified>DATE and modified>=DATE match the last modified date (YYYY-MM-DD or RFC 3339).
+//     A date without time means the whole day in UTC, e.g. "modified<=2023-06-01" includes the day.
+//   - A term without a field, such as "api", matches a part of the name.
+//   - A term prefixed with - is negated, such as "-name:test".
+//
+// Values except tags are case-insensitive, and can be quoted by double quotes to contain spaces.
+func ParseQuery(s string) (*Query, error) {
+	tokens, err := tokenizeQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &Query{terms: []queryTerm{}}
+	for _, token := range tokens {
+		term, err := parseQueryTerm(token)
+		if err != nil {
+			return nil, err
+		}
+		query.terms = append(query.terms, term)
+	}
+	return query, nil
+}
+
+// IsQuery reports whether the text has a term with a field or an operator, such as "name:api" or "modified<2023-06-01".
+// A text without them, such as "-prod" or "my func", is a plain keyword for a part of the function name.
+func IsQuery(s string) bool {
+	for _, field := range strings.Fields(s) {
+		if queryTermPattern.MatchString(strings.TrimPrefix(field, "-")) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty reports whether the query has no terms, matching all functions.
+func (q *Query) IsEmpty() bool {
+	return q == nil || len(q.terms) == 0
+}
+
+// NeedsTags reports whether the query needs the tags of functions, which need an API call per function.
+func (q *Query) NeedsTags() bool {
+	if q == nil {
+		return false
+	}
+	for _, term := range q.terms {
+		if term.needsTags {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Query) matches(target *queryTarget) bool {
+	if q == nil {
+		return true
+	}
+	for _, term := range q.terms {
+		if term.match(target) == term.negated {
+			return false
+		}
+	}
+	return true
+}
+
+func tokenizeQuery(s string) ([]string, error) {
+	tokens := []string{}
+	var token strings.Builder
+	inQuotes := false
+	hasToken := false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasToken = true
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if hasToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				hasToken = false
+			}
+		default:
+			token.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in query: %s", s)
+	}
+	if hasToken {
+		tokens = append(tokens, token.String())
+	}
+	return tokens, nil
+}
+
+func parseQueryTerm(token string) (queryTerm, error) {
+	term := queryTerm{raw: token}
+	body := token
+	if strings.HasPrefix(body, "-") {
+		term.negated = true
+		body = body[1:]
+	}
+	if body == "" {
+		return term, fmt.Errorf("empty query term: %q", token)
+	}
+
+	var (
+		match func(target *queryTarget) bool
+		err   error
+	)
+	matches := queryTermPattern.FindStringSubmatch(body)
+	if matches == nil {
+		// a term without a field is a part of the name, as the keyword
+		match, err = newNameMatcher(body)
+		return withMatcher(term, match, err)
+	}
+	field, operator, value := strings.ToLower(matches[1]), matches[2], matches[3]
+
+	if !slices.Contains(queryFields, field) {
+		return term, fmt.Errorf("unknown field %q in query term %q (fields: %s)", field, token, strings.Join(queryFields, ", "))
+	}
+	if value == "" {
+		return term, fmt.Errorf("empty value in query term %q", token)
+	}
+	if field == QueryFieldModified {
+		if operator == ":" {
+			return term, fmt.Errorf("use <, <=, > or >= for %s in query term %q (e.g. modified<2023-06-01)", field, token)
+		}
+		match, err = newModifiedMatcher(operator, value)
+		return withMatcher(term, match, err)
+	}
+	if operator != ":" {
+		return term, fmt.Errorf("use : for %s in query term %q (e.g. %s:VALUE)", field, token, field)
+	}
+
+	switch field {
+	case QueryFieldName:
+		match, err = newNameMatcher(value)
+	case QueryFieldRuntime:
+		match, err = newValueMatcher(value, func(t *queryTarget) []string { return []string{t.runtime} })
+	case QueryFieldRegion:
+		match, err = newValueMatcher(value, func(t *queryTarget) []string { return []string{t.region} })
+	case QueryFieldArch:
+		match, err = newValueMatcher(value, func(t *queryTarget) []string {
+			// functions without architectures run on x86_64
+			if len(t.architectures) == 0 {
+				return []string{string(lambdaTypes.ArchitectureX8664)}
+			}
+			return t.architectures
+		})
+	default:
+		term.needsTags = true
+		match, err = newTagMatcher(value)
+	}
+	return withMatcher(term, match, err)
+}
+
+func withMatcher(term queryTerm, match func(target *queryTarget) bool, err error) (queryTerm, error) {
+	if err != nil {
+		return term, fmt.Errorf("%w in query term %q", err, term.raw)
+	}
+	term.match = match
+	return term, nil
+}
+
+// compilePatterns validates the comma-separated patterns with wildcards, and lowers them for case-insensitive matching.
+func compilePatterns(value string) ([]string, error) {
+	patterns := []string{}
+	for _, pattern := range strings.Split(strings.ToLower(value), ",") {
+		if pattern == "" {
+			return nil, fmt.Errorf("empty alternative")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q", pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func hasWildcard(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+func newNameMatcher(value string) (func(target *queryTarget) bool, error) {
+	patterns, err := compilePatterns(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(target *queryTarget) bool {
+		name := strings.ToLower(target.name)
+		for _, pattern := range patterns {
+			if hasWildcard(pattern) {
+				if ok, _ := path.Match(pattern, name); ok {
+					return true
+				}
+			} else if strings.Contains(name, pattern) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func newValueMatcher(value string, values func(target *queryTarget) []string) (func(target *queryTarget) bool, error) {
+	patterns, err := compilePatterns(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(target *queryTarget) bool {
+		for _, v := range values(target) {
+			for _, pattern := range patterns {
+				if ok, _ := path.Match(pattern, strings.ToLower(v)); ok {
+					return true
+				}
+			}
+		}
+		return false
+	}, nil
+}
+
+func newTagMatcher(value string) (func(target *queryTarget) bool, error) {
+	key, tagValue, hasValue := strings.Cut(value, "=")
+	if key == "" {
+		return nil, fmt.Errorf("empty tag key")
+	}
+	return func(target *queryTarget) bool {
+		v, ok := target.tags[key]
+		if !ok {
+			return false
+		}
+		return !hasValue || v == tagValue
+	}, nil
+}
+
+func newModifiedMatcher(operator string, value string) (func(target *queryTarget) bool, error) {
+	date, err := ParseDate(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q (YYYY-MM-DD or RFC 3339)", value)
+	}
+	// a date without time means the whole day, so <= and > compare with the start of the next day
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		switch operator {
+		case "<=":
+			date, operator = date.AddDate(0, 0, 1), "<"
+		case ">":
+			date, operator = date.AddDate(0, 0, 1), ">="
+		}
+	}
+	return func(target *queryTarget) bool {
+		// functions with an unknown last modified time do not match any date
+		if target.lastModified.IsZero() {
+			return false
+		}
+		switch operator {
+		case "<":
+			return target.lastModified.Before(date)
+		case "<=":
+			return !target.lastModified.After(date)
+		case ">":
+			return target.lastModified.After(date)
+		default:
+			return !target.lastModified.Before(date)
+		}
+	}, nil
+}
@@ -0,0 +1,201 @@
+package action
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantTerms     int
+		wantNeedsTags bool
+		wantErr       string
+	}{
+		{
+			name:      "ParseQuery success with all fields",
+			query:     `name:api runtime:python3.* region:eu-* -name:test modified<2023-06-01 arch:arm64 api`,
+			wantTerms: 7,
+		},
+		{
+			name:          "ParseQuery success with tags",
+			query:         "tag:team=core -tag:deprecated",
+			wantTerms:     2,
+			wantNeedsTags: true,
+		},
+		{
+			name:      "ParseQuery success with quotes and spaces",
+			query:     `  name:"my func"   -test  `,
+			wantTerms: 2,
+		},
+		{
+			name:      "ParseQuery success with empty query",
+			query:     "",
+			wantTerms: 0,
+		},
+		{
+			name:    "ParseQuery fail with unknown field",
+			query:   "nmae:api",
+			wantErr: `unknown field "nmae" in query term "nmae:api" (fields: name, runtime, region, tag, arch, modified)`,
+		},
+		{
+			name:    "ParseQuery fail with empty value",
+			query:   "runtime:",
+			wantErr: `empty value in query term "runtime:"`,
+		},
+		{
+			name:    "ParseQuery fail with only negation",
+			query:   "api -",
+			wantErr: `empty query term: "-"`,
+		},
+		{
+			name:    "ParseQuery fail with invalid date",
+			query:   "modified<2023/06/01",
+			wantErr: `invalid date "2023/06/01" (YYYY-MM-DD or RFC 3339) in query term "modified<2023/06/01"`,
+		},
+		{
+			name:    "ParseQuery fail with colon for modified",
+			query:   "modified:2023-06-01",
+			wantErr: `use <, <=, > or >= for modified in query term "modified:2023-06-01" (e.g. modified<2023-06-01)`,
+		},
+		{
+			name:    "ParseQuery fail with comparison for name",
+			query:   "name<api",
+			wantErr: `use : for name in query term "name<api" (e.g. name:VALUE)`,
+		},
+		{
+			name:    "ParseQuery fail with invalid pattern",
+			query:   "runtime:python[",
+			wantErr: `invalid pattern "python[" in query term "runtime:python["`,
+		},
+		{
+			name:    "ParseQuery fail with empty alternative",
+			query:   "region:eu-*,",
+			wantErr: `empty alternative in query term "region:eu-*,"`,
+		},
+		{
+			name:    "ParseQuery fail with empty tag key",
+			query:   "tag:=core",
+			wantErr: `empty tag key in query term "tag:=core"`,
+		},
+		{
+			name:    "ParseQuery fail with unterminated quote",
+			query:   `name:"my func`,
+			wantErr: `unterminated quote in query: name:"my func`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ParseQuery() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if len(got.terms) != tt.wantTerms {
+				t.Errorf("ParseQuery() terms = %d, want %d", len(got.terms), tt.wantTerms)
+			}
+			if got.NeedsTags() != tt.wantNeedsTags {
+				t.Errorf("NeedsTags() = %v, want %v", got.NeedsTags(), tt.wantNeedsTags)
+			}
+			if got.IsEmpty() != (tt.wantTerms == 0) {
+				t.Errorf("IsEmpty() = %v, want %v", got.IsEmpty(), tt.wantTerms == 0)
+			}
+		})
+	}
+}
+
+func TestIsQuery(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{s: "", want: false},
+		{s: "api", want: false},
+		{s: "-prod", want: false},
+		{s: "my func", want: false},
+		{s: "name:api", want: true},
+		{s: "api -name:test", want: true},
+		{s: "modified<2023-06-01", want: true},
+		{s: "nmae:api", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := IsQuery(tt.s); got != tt.want {
+				t.Errorf("IsQuery(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_matches(t *testing.T) {
+	target := &queryTarget{
+		name:         "Orders-API-Handler",
+		runtime:      "python3.9",
+		region:       "eu-west-1",
+		lastModified: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		tags:         map[string]string{"team": "core", "env": "prod"},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "", want: true},
+		{query: "api", want: true},
+		{query: "name:api", want: true},
+		{query: "name:API -name:test", want: true},
+		{query: "api -handler", want: false},
+		{query: "name:orders-*", want: true},
+		{query: "name:api-*", want: false},
+		{query: "name:batch,handler", want: true},
+		{query: "runtime:python3.*", want: true},
+		{query: "runtime:python3", want: false},
+		{query: "runtime:nodejs*,python3.9", want: true},
+		{query: "region:eu-*", want: true},
+		{query: "-region:eu-*", want: false},
+		{query: "arch:x86_64", want: true},
+		{query: "arch:arm64", want: false},
+		{query: "tag:team=core", want: true},
+		{query: "tag:team=web", want: false},
+		{query: "tag:env", want: true},
+		{query: "-tag:deprecated", want: true},
+		{query: "modified<2023-06-01", want: true},
+		{query: "modified>=2023-06-01", want: false},
+		{query: "modified>2023-04-30 modified<=2023-05-02", want: true},
+		// a date means the whole day, and the target is modified at noon of the day
+		{query: "modified<=2023-05-01", want: true},
+		{query: "modified>2023-05-01", want: false},
+		{query: "modified<2023-05-01", want: false},
+		{query: "modified>=2023-05-01", want: true},
+		{query: "modified<=2023-05-01T00:00:00Z", want: false},
+		{query: "modified>2023-05-01T00:00:00Z", want: true},
+		{query: "name:api runtime:python3.* region:eu-* -name:test modified<2023-06-01 tag:team=core", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if got := query.matches(target); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_matchesUnknownLastModified(t *testing.T) {
+	query, err := ParseQuery("modified<2023-06-01")
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	if query.matches(&queryTarget{name: "Function"}) {
+		t.Error("matches() = true, want false for an unknown last modified time")
+	}
+}
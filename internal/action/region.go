@@ -0,0 +1,59 @@
+package action
+
+import (
+	"context"
+	"runtime"
+	"sort"
+
+	"github.com/go-to-k/lamver/internal/types"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+// forEachRegion calls fn for each region in parallel, for at most concurrency regions at the same time.
+// The concurrency defaults to the number of CPUs. The first error is returned, and cancels the context
+// passed to the other calls.
+func forEachRegion(ctx context.Context, concurrency int, regions []string, fn func(ctx context.Context, region string) error) error {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(int64(concurrency))
+
+	for _, region := range regions {
+		region := region
+		if err := sem.Acquire(ctx, 1); err != nil {
+			// the error canceling the context is returned rather than the cancellation
+			if egErr := eg.Wait(); egErr != nil {
+				return egErr
+			}
+			return err
+		}
+		eg.Go(func() error {
+			defer sem.Release(1)
+			return fn(ctx, region)
+		})
+	}
+
+	return eg.Wait()
+}
+
+func groupFunctionIndexesByRegion(functions []types.LambdaFunctionData) map[string][]int {
+	indexesByRegion := make(map[string][]int)
+	for i, f := range functions {
+		indexesByRegion[f.Region] = append(indexesByRegion[f.Region], i)
+	}
+	return indexesByRegion
+}
+
+// regionsOf returns the regions of the grouped indexes, sorted by the name.
+func regionsOf(indexesByRegion map[string][]int) []string {
+	regions := make([]string, 0, len(indexesByRegion))
+	for region := range indexesByRegion {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
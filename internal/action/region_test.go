@@ -0,0 +1,74 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func Test_forEachRegion(t *testing.T) {
+	regions := []string{"ap-northeast-1", "eu-west-1", "us-east-1", "us-west-2"}
+
+	tests := []struct {
+		name        string
+		concurrency int
+		failRegion  string
+		wantErr     bool
+	}{
+		{
+			name:        "forEachRegion success with a concurrency",
+			concurrency: 2,
+		},
+		{
+			name:        "forEachRegion success with the default concurrency",
+			concurrency: 0,
+		},
+		{
+			name:        "forEachRegion fail by an error in a region",
+			concurrency: 1,
+			failRegion:  "eu-west-1",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu := sync.Mutex{}
+			running, maxRunning := 0, 0
+			called := []string{}
+
+			err := forEachRegion(context.Background(), tt.concurrency, regions, func(ctx context.Context, region string) error {
+				mu.Lock()
+				running++
+				maxRunning = max(maxRunning, running)
+				called = append(called, region)
+				mu.Unlock()
+
+				defer func() {
+					mu.Lock()
+					running--
+					mu.Unlock()
+				}()
+
+				if region == tt.failRegion {
+					return fmt.Errorf("ListFunctionsError")
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("forEachRegion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.concurrency > 0 && maxRunning > tt.concurrency {
+				t.Errorf("forEachRegion() ran %d regions at the same time, want at most %d", maxRunning, tt.concurrency)
+			}
+			if tt.wantErr {
+				return
+			}
+			sort.Strings(called)
+			if fmt.Sprint(called) != fmt.Sprint(regions) {
+				t.Errorf("forEachRegion() called %v, want %v", called, regions)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package action
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-to-k/lamver/internal/types"
+)
+
+const (
+	SortFieldRuntime      = "runtime"
+	SortFieldRegion       = "region"
+	SortFieldName         = "name"
+	SortFieldLastModified = "lastModified"
+	SortFieldCodeSize     = "codeSize"
+)
+
+var sortFields = []string{SortFieldRuntime, SortFieldRegion, SortFieldName, SortFieldLastModified, SortFieldCodeSize}
+
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSortKeys parses comma-separated sort keys such as "lastModified,-codeSize,name".
+// A key prefixed with "-" is sorted in descending order.
+func ParseSortKeys(s string) ([]SortKey, error) {
+	keys := []SortKey{}
+	if strings.TrimSpace(s) == "" {
+		return keys, nil
+	}
+
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		key := SortKey{}
+		if strings.HasPrefix(field, "-") {
+			key.Descending = true
+			field = field[1:]
+		}
+
+		for _, f := range sortFields {
+			if strings.EqualFold(f, field) {
+				key.Field = f
+				break
+			}
+		}
+		if key.Field == "" {
+			return nil, fmt.Errorf("invalid sort key: %q, must be one of %s", field, strings.Join(sortFields, ", "))
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// SortFunctions sorts functions by the keys. Runtime values are compared in the order of runtimeOrder,
+// and functions with equal keys keep their original order.
+func SortFunctions(functions []types.LambdaFunctionData, keys []SortKey, runtimeOrder []string) {
+	runtimeIndex := make(map[string]int, len(runtimeOrder))
+	for i, r := range runtimeOrder {
+		runtimeIndex[r] = i
+	}
+
+	sort.SliceStable(functions, func(i, j int) bool {
+		for _, key := range keys {
+			c := compareFunctions(&functions[i], &functions[j], key.Field, runtimeIndex)
+			if c == 0 {
+				continue
+			}
+			if key.Descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+func compareFunctions(a *types.LambdaFunctionData, b *types.LambdaFunctionData, field string, runtimeIndex map[string]int) int {
+	switch field {
+	case SortFieldRuntime:
+		ai, aok := runtimeIndex[string(a.Runtime)]
+		bi, bok := runtimeIndex[string(b.Runtime)]
+		if aok && bok {
+			return compareInts(int64(ai), int64(bi))
+		}
+		return strings.Compare(string(a.Runtime), string(b.Runtime))
+	case SortFieldRegion:
+		return strings.Compare(a.Region, b.Region)
+	case SortFieldName:
+		return strings.Compare(a.FunctionName, b.FunctionName)
+	case SortFieldLastModified:
+		return a.LastModified.Compare(b.LastModified)
+	case SortFieldCodeSize:
+		return compareInts(a.CodeSize, b.CodeSize)
+	}
+	return 0
+}
+
+func compareInts(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
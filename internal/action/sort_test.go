@@ -0,0 +1,128 @@
+package action
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-to-k/lamver/internal/types"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []SortKey
+		wantErr bool
+	}{
+		{
+			name: "ParseSortKeys success",
+			s:    "lastModified,-codeSize,name",
+			want: []SortKey{
+				{Field: SortFieldLastModified},
+				{Field: SortFieldCodeSize, Descending: true},
+				{Field: SortFieldName},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ParseSortKeys success with case-insensitive keys and spaces",
+			s:    " LASTMODIFIED , -Region",
+			want: []SortKey{
+				{Field: SortFieldLastModified},
+				{Field: SortFieldRegion, Descending: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ParseSortKeys success with empty string",
+			s:       "",
+			want:    []SortKey{},
+			wantErr: false,
+		},
+		{
+			name:    "ParseSortKeys fail with unknown key",
+			s:       "name,memory",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "ParseSortKeys fail with empty key",
+			s:       "name,",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSortKeys(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSortKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSortKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortFunctions(t *testing.T) {
+	functions := func() []types.LambdaFunctionData {
+		return []types.LambdaFunctionData{
+			{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "us-east-1", FunctionName: "Function1", LastModified: mustParseLastModified("2023-01-01T00:00:00.000+0000"), CodeSize: 300},
+			{Runtime: lambdaTypes.RuntimeNodejs, Region: "ap-northeast-1", FunctionName: "Function2", LastModified: mustParseLastModified("2021-01-01T00:00:00.000+0000"), CodeSize: 100},
+			{Runtime: lambdaTypes.RuntimeNodejs, Region: "us-east-1", FunctionName: "Function3", LastModified: mustParseLastModified("2022-01-01T09:00:00.000+0900"), CodeSize: 300},
+			{Runtime: lambdaTypes.RuntimeNodejs18x, Region: "ap-northeast-1", FunctionName: "Function4", LastModified: mustParseLastModified("2021-12-31T23:00:00.000+0000"), CodeSize: 200},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		keys         []SortKey
+		runtimeOrder []string
+		wantNames    []string
+	}{
+		{
+			name:      "SortFunctions by lastModified compares real timestamps",
+			keys:      []SortKey{{Field: SortFieldLastModified}},
+			wantNames: []string{"Function2", "Function4", "Function3", "Function1"},
+		},
+		{
+			name:      "SortFunctions by codeSize descending and name",
+			keys:      []SortKey{{Field: SortFieldCodeSize, Descending: true}, {Field: SortFieldName, Descending: true}},
+			wantNames: []string{"Function3", "Function1", "Function4", "Function2"},
+		},
+		{
+			name:         "SortFunctions by runtime in the runtime order and region",
+			keys:         []SortKey{{Field: SortFieldRuntime}, {Field: SortFieldRegion}},
+			runtimeOrder: []string{"nodejs", "nodejs18.x"},
+			wantNames:    []string{"Function2", "Function3", "Function4", "Function1"},
+		},
+		{
+			name:      "SortFunctions keeps the original order with equal keys",
+			keys:      []SortKey{{Field: SortFieldCodeSize}},
+			wantNames: []string{"Function2", "Function4", "Function1", "Function3"},
+		},
+		{
+			name:      "SortFunctions keeps the original order with no keys",
+			keys:      []SortKey{},
+			wantNames: []string{"Function1", "Function2", "Function3", "Function4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := functions()
+			SortFunctions(got, tt.keys, tt.runtimeOrder)
+
+			gotNames := []string{}
+			for _, f := range got {
+				gotNames = append(gotNames, f.FunctionName)
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("SortFunctions() = %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
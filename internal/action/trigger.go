@@ -0,0 +1,192 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// PublicTrigger is the trigger summary of a policy statement allowing any principal.
+const PublicTrigger = "public"
+
+type policyDocument struct {
+	// Statement is decoded one by one, so that a statement that cannot be parsed does not fail the others.
+	Statement []json.RawMessage `json:"Statement"`
+}
+
+type policyStatement struct {
+	Effect    string          `json:"Effect"`
+	Principal policyPrincipal `json:"Principal"`
+	// Condition values are not only strings but also booleans and numbers, e.g. {"Bool": {"aws:SecureTransport": true}}.
+	Condition map[string]map[string]json.RawMessage `json:"Condition"`
+}
+
+// policyPrincipal is either "*" or a map such as {"Service": "s3.amazonaws.com"}.
+type policyPrincipal struct {
+	Any     bool
+	Service []string
+	AWS     []string
+}
+
+func (p *policyPrincipal) UnmarshalJSON(data []byte) error {
+	var wildcard string
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		p.Any = wildcard == "*"
+		return nil
+	}
+
+	var principals map[string]stringOrStrings
+	if err := json.Unmarshal(data, &principals); err != nil {
+		return err
+	}
+	p.Service = principals["Service"]
+	p.AWS = principals["AWS"]
+	return nil
+}
+
+type stringOrStrings []string
+
+func (s *stringOrStrings) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []string{single}
+		return nil
+	}
+
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	*s = multi
+	return nil
+}
+
+// summarizePolicyTriggers summarizes the principals allowed to invoke the function by its resource-based policy,
+// e.g. "s3:my-bucket", "events:rule/my-rule" or "account:123456789012".
+func summarizePolicyTriggers(policy string) ([]string, error) {
+	if policy == "" {
+		return []string{}, nil
+	}
+
+	var document policyDocument
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return []string{}, fmt.Errorf("failed to parse the function policy: %w", err)
+	}
+
+	triggers := []string{}
+	for _, rawStatement := range document.Statement {
+		var statement policyStatement
+		if err := json.Unmarshal(rawStatement, &statement); err != nil {
+			// statements in unexpected forms are skipped, not to stop the search
+			continue
+		}
+		if statement.Effect != "Allow" {
+			continue
+		}
+		sourceArn := getSourceArn(statement.Condition)
+
+		if statement.Principal.Any {
+			triggers = append(triggers, PublicTrigger)
+		}
+		for _, service := range statement.Principal.Service {
+			trigger := strings.TrimSuffix(service, ".amazonaws.com")
+			if resource := getArnResource(sourceArn); resource != "" {
+				trigger += ":" + resource
+			}
+			triggers = append(triggers, trigger)
+		}
+		for _, principal := range statement.Principal.AWS {
+			if principal == "*" {
+				triggers = append(triggers, PublicTrigger)
+				continue
+			}
+			triggers = append(triggers, "account:"+getAccountID(principal))
+		}
+	}
+
+	return triggers, nil
+}
+
+func getSourceArn(condition map[string]map[string]json.RawMessage) string {
+	for _, values := range condition {
+		for key, rawValue := range values {
+			if !strings.EqualFold(key, "AWS:SourceArn") {
+				continue
+			}
+			var value stringOrStrings
+			if err := json.Unmarshal(rawValue, &value); err == nil && len(value) > 0 {
+				return value[0]
+			}
+		}
+	}
+	return ""
+}
+
+// summarizeEventSourceMapping summarizes the event source of the mapping, e.g. "sqs:my-queue" or "kinesis:stream/my-stream".
+func summarizeEventSourceMapping(mapping lambdaTypes.EventSourceMappingConfiguration) string {
+	eventSourceArn := aws.ToString(mapping.EventSourceArn)
+	if eventSourceArn == "" {
+		if mapping.SelfManagedEventSource != nil {
+			return "kafka:self-managed"
+		}
+		return "unknown"
+	}
+
+	service := getArnService(eventSourceArn)
+	if resource := getArnResource(eventSourceArn); resource != "" {
+		return service + ":" + resource
+	}
+	return service
+}
+
+// unqualifyFunctionArn removes a version or alias from the function ARN.
+func unqualifyFunctionArn(functionArn string) string {
+	parts := strings.Split(functionArn, ":")
+	if len(parts) > 7 {
+		return strings.Join(parts[:7], ":")
+	}
+	return functionArn
+}
+
+func getArnService(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return ""
+	}
+	return parts[2]
+}
+
+func getArnResource(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return ""
+	}
+	return parts[5]
+}
+
+// getAccountID returns the account ID of an AWS principal, which is either an account ID or an ARN.
+func getAccountID(principal string) string {
+	parts := strings.SplitN(principal, ":", 6)
+	if len(parts) < 6 {
+		return principal
+	}
+	return parts[4]
+}
+
+func uniqueSortedTriggers(triggers []string) []string {
+	seen := make(map[string]struct{}, len(triggers))
+	unique := make([]string, 0, len(triggers))
+	for _, trigger := range triggers {
+		if _, ok := seen[trigger]; ok {
+			continue
+		}
+		seen[trigger] = struct{}{}
+		unique = append(unique, trigger)
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,203 @@
+package action
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+func Test_summarizePolicyTriggers(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "summarizePolicyTriggers with no policy",
+			policy:  "",
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name: "summarizePolicyTriggers with boolean and numeric conditions",
+			policy: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"Service": "s3.amazonaws.com"},
+						"Action": "lambda:InvokeFunction",
+						"Condition": {
+							"Bool": {"aws:SecureTransport": true},
+							"NumericLessThan": {"aws:MultiFactorAuthAge": 3600},
+							"ArnLike": {"AWS:SourceArn": "arn:aws:s3:::my-bucket"}
+						}
+					}
+				]
+			}`,
+			want:    []string{"s3:my-bucket"},
+			wantErr: false,
+		},
+		{
+			name: "summarizePolicyTriggers skips statements that cannot be parsed",
+			policy: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"Service": 1},
+						"Action": "lambda:InvokeFunction"
+					},
+					{
+						"Effect": "Allow",
+						"Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+						"Action": "lambda:InvokeFunction"
+					}
+				]
+			}`,
+			want:    []string{"account:123456789012"},
+			wantErr: false,
+		},
+		{
+			name: "summarizePolicyTriggers with service principals",
+			policy: `{
+				"Version": "2012-10-17",
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"Service": "s3.amazonaws.com"},
+						"Action": "lambda:InvokeFunction",
+						"Condition": {"ArnLike": {"AWS:SourceArn": "arn:aws:s3:::my-bucket"}}
+					},
+					{
+						"Effect": "Allow",
+						"Principal": {"Service": "events.amazonaws.com"},
+						"Action": "lambda:InvokeFunction",
+						"Condition": {"ArnLike": {"AWS:SourceArn": "arn:aws:events:us-east-1:123456789012:rule/my-rule"}}
+					},
+					{
+						"Effect": "Allow",
+						"Principal": {"Service": "apigateway.amazonaws.com"},
+						"Action": "lambda:InvokeFunction"
+					}
+				]
+			}`,
+			want:    []string{"s3:my-bucket", "events:rule/my-rule", "apigateway"},
+			wantErr: false,
+		},
+		{
+			name: "summarizePolicyTriggers with account and public principals",
+			policy: `{
+				"Statement": [
+					{
+						"Effect": "Allow",
+						"Principal": {"AWS": ["arn:aws:iam::210987654321:root", "123456789012"]},
+						"Action": "lambda:InvokeFunction"
+					},
+					{
+						"Effect": "Allow",
+						"Principal": "*",
+						"Action": "lambda:InvokeFunctionUrl",
+						"Condition": {"StringEquals": {"lambda:FunctionUrlAuthType": "NONE"}}
+					},
+					{
+						"Effect": "Deny",
+						"Principal": {"Service": "sns.amazonaws.com"},
+						"Action": "lambda:InvokeFunction"
+					}
+				]
+			}`,
+			want:    []string{"account:210987654321", "account:123456789012", PublicTrigger},
+			wantErr: false,
+		},
+		{
+			name:    "summarizePolicyTriggers with invalid policy",
+			policy:  `{"Statement": "invalid"}`,
+			want:    []string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := summarizePolicyTriggers(tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("summarizePolicyTriggers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("summarizePolicyTriggers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_summarizeEventSourceMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping lambdaTypes.EventSourceMappingConfiguration
+		want    string
+	}{
+		{
+			name: "summarizeEventSourceMapping with SQS",
+			mapping: lambdaTypes.EventSourceMappingConfiguration{
+				EventSourceArn: aws.String("arn:aws:sqs:us-east-1:123456789012:my-queue"),
+			},
+			want: "sqs:my-queue",
+		},
+		{
+			name: "summarizeEventSourceMapping with Kinesis",
+			mapping: lambdaTypes.EventSourceMappingConfiguration{
+				EventSourceArn: aws.String("arn:aws:kinesis:us-east-1:123456789012:stream/my-stream"),
+			},
+			want: "kinesis:stream/my-stream",
+		},
+		{
+			name: "summarizeEventSourceMapping with self-managed Kafka",
+			mapping: lambdaTypes.EventSourceMappingConfiguration{
+				SelfManagedEventSource: &lambdaTypes.SelfManagedEventSource{},
+			},
+			want: "kafka:self-managed",
+		},
+		{
+			name:    "summarizeEventSourceMapping with no event source",
+			mapping: lambdaTypes.EventSourceMappingConfiguration{},
+			want:    "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeEventSourceMapping(tt.mapping); got != tt.want {
+				t.Errorf("summarizeEventSourceMapping() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unqualifyFunctionArn(t *testing.T) {
+	tests := []struct {
+		name        string
+		functionArn string
+		want        string
+	}{
+		{
+			name:        "unqualifyFunctionArn with unqualified ARN",
+			functionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+			want:        "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+		},
+		{
+			name:        "unqualifyFunctionArn with alias",
+			functionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1:live",
+			want:        "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unqualifyFunctionArn(tt.functionArn); got != tt.want {
+				t.Errorf("unqualifyFunctionArn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,16 +2,28 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-to-k/lamver/internal/action"
 	"github.com/go-to-k/lamver/internal/io"
+	"github.com/go-to-k/lamver/internal/ownership"
+	"github.com/go-to-k/lamver/internal/runtimecatalog"
+	"github.com/go-to-k/lamver/internal/state"
 	"github.com/go-to-k/lamver/internal/types"
 	"github.com/go-to-k/lamver/pkg/client"
+	"github.com/go-to-k/lamver/pkg/runtimeversion"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"github.com/urfave/cli/v2"
 )
 
@@ -21,12 +33,52 @@ type App struct {
 	Cli                 *cli.App
 	Profile             string
 	DefaultRegion       string
-	CSVOutputFilePath   string
+	Partition           string
+	OutputFilePath      string
+	Format              string
 	FunctionNameKeyword string
+	Query               string
+	IncludeNotOptedIn   bool
+	SortBy              string
+	ModifiedBefore      string
+	ModifiedAfter       string
+	OlderThan           string
+	WithInvocations     string
+	WithTriggers        bool
+	WithURL             bool
+	WithVPC             bool
+	PublicOnly          bool
+	InVPC               bool
+	NoVPC               bool
+	WithConfig          bool
+	Missing             string
+	Architecture        string
+	RuntimeExpression   string
+	RuntimeFamily       string
+	RuntimeCatalog      string
+	PresentOnly         bool
+	ScanRegions         bool
+	NoRemember          bool
+	Yes                 bool
+	OwnerTag            string
+	OwnerMap            string
+	OwnerOutputDir      string
+	// Prompter asks the user. It is replaced by a scripted one in tests.
+	Prompter io.Prompter
+
+	// the clients are created by these functions, replaced by mocks in tests
+	newEC2Client        func(cfg aws.Config) client.EC2Client
+	newLambdaClient     func(cfg aws.Config) client.LambdaClient
+	newCloudWatchClient func(cfg aws.Config) client.CloudWatchClient
 }
 
 func NewApp(version string) *App {
-	app := App{}
+	app := App{
+		Prompter:            io.NewTUIPrompter(),
+		newEC2Client:        newEC2Client,
+		newLambdaClient:     newLambdaClient,
+		newCloudWatchClient: newCloudWatchClient,
+	}
 
 	app.Cli = &cli.App{
 		Name:  "lamver",
@@ -45,10 +97,21 @@ func NewApp(version string) *App {
 				Destination: &app.DefaultRegion,
 			},
 			&cli.StringFlag{
-				Name:        "output",
-				Aliases:     []string{"o"},
-				Usage:       "Output file path for CSV format",
-				Destination: &app.CSVOutputFilePath,
+				Name:        "partition",
+				Usage:       "AWS partition (aws|aws-us-gov|aws-cn). Detected from the region if not specified",
+				Destination: &app.Partition,
+			},
+			&cli.StringFlag{
+				Name:        "output-file",
+				Aliases:     []string{"o", "output"},
+				Usage:       "Output file path, or - for stdout (CSV format by default for a file)",
+				Destination: &app.OutputFilePath,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Aliases:     []string{"f"},
+				Usage:       "Output format (" + strings.Join(io.GetFormats(), "|") + "). Default is table, or csv if an output file is specified",
+				Destination: &app.Format,
 			},
 			&cli.StringFlag{
 				Name:        "keyword",
@@ -56,11 +119,164 @@ func NewApp(version string) *App {
 				Usage:       "Keyword for function name filtering (case-insensitive)",
 				Destination: &app.FunctionNameKeyword,
 			},
+			&cli.StringFlag{
+				Name:        "query",
+				Aliases:     []string{"q"},
+				Usage:       "Search query instead of the keyword prompt (e.g. \"name:api runtime:python3.* -name:test tag:team=core\")",
+				Destination: &app.Query,
+			},
+			&cli.StringFlag{
+				Name:        "modified-before",
+				Usage:       "Filter functions last modified before the date (YYYY-MM-DD or RFC 3339)",
+				Destination: &app.ModifiedBefore,
+			},
+			&cli.StringFlag{
+				Name:        "modified-after",
+				Usage:       "Filter functions last modified at or after the date (YYYY-MM-DD or RFC 3339)",
+				Destination: &app.ModifiedAfter,
+			},
+			&cli.StringFlag{
+				Name:        "older-than",
+				Usage:       "Filter functions not modified for the age (e.g. 365d, 4w, 36h)",
+				Destination: &app.OlderThan,
+			},
+			&cli.StringFlag{
+				Name:        "with-invocations",
+				Usage:       "Add invocations, errors and the last invoked day in the period (e.g. 30d) from CloudWatch metrics",
+				Destination: &app.WithInvocations,
+			},
+			&cli.BoolFlag{
+				Name:        "with-triggers",
+				Usage:       "Add triggers from event source mappings and function policies",
+				Destination: &app.WithTriggers,
+			},
+			&cli.BoolFlag{
+				Name:        "with-url",
+				Usage:       "Add the auth type of function URLs",
+				Destination: &app.WithURL,
+			},
+			&cli.BoolFlag{
+				Name:        "with-vpc",
+				Usage:       "Add the VPC, subnets and security groups of functions",
+				Destination: &app.WithVPC,
+			},
+			&cli.BoolFlag{
+				Name:        "public-only",
+				Usage:       "Filter functions with a function URL that needs no auth (implies --with-url)",
+				Destination: &app.PublicOnly,
+			},
+			&cli.BoolFlag{
+				Name:        "in-vpc",
+				Usage:       "Filter functions attached to a VPC (implies --with-vpc)",
+				Destination: &app.InVPC,
+			},
+			&cli.BoolFlag{
+				Name:        "no-vpc",
+				Usage:       "Filter functions not attached to a VPC",
+				Destination: &app.NoVPC,
+			},
+			&cli.StringFlag{
+				Name:        "runtime",
+				Usage:       "Select runtime values by expressions instead of the checkboxes (e.g. \"nodejs<18\", \"python>=3.9,<3.11\"). Separate multiple expressions by ;",
+				Destination: &app.RuntimeExpression,
+			},
+			&cli.StringFlag{
+				Name:        "runtime-family",
+				Usage:       "Select all runtime values of the comma-separated families instead of the checkboxes (e.g. java,python)",
+				Destination: &app.RuntimeFamily,
+			},
+			&cli.StringFlag{
+				Name:        "runtime-catalog",
+				Usage:       "JSON or YAML file of the runtime values to select from, instead of the embedded catalog",
+				Destination: &app.RuntimeCatalog,
+			},
+			&cli.BoolFlag{
+				Name:        "present-only",
+				Usage:       "Show only runtime values of functions in the selected regions, with the number of functions",
+				Destination: &app.PresentOnly,
+			},
+			&cli.StringFlag{
+				Name:        "arch",
+				Usage:       "Filter functions by the architecture (x86_64|arm64)",
+				Destination: &app.Architecture,
+			},
+			&cli.BoolFlag{
+				Name:        "with-config",
+				Usage:       "Add tracing, SnapStart, logging, dead-letter queue and code signing configurations",
+				Destination: &app.WithConfig,
+			},
+			&cli.StringFlag{
+				Name:        "missing",
+				Usage:       "Filter functions missing any of the comma-separated configurations (tracing|dlq|snapstart|code-signing) (implies --with-config)",
+				Destination: &app.Missing,
+			},
+			&cli.StringFlag{
+				Name:        "sort-by",
+				Usage:       "Comma-separated sort keys (runtime|region|name|lastModified|codeSize). Prefix a key with - for descending order",
+				Destination: &app.SortBy,
+			},
+			&cli.BoolFlag{
+				Name:        "scan-regions",
+				Usage:       "Show the number of functions in each region in the region selection, by listing them first",
+				Destination: &app.ScanRegions,
+			},
+			&cli.BoolFlag{
+				Name:        "no-remember",
+				Usage:       "Do not preselect the last selections of regions, runtime values and a keyword, nor remember them",
+				Destination: &app.NoRemember,
+			},
+			&cli.BoolFlag{
+				Name:        "yes",
+				Aliases:     []string{"y"},
+				Usage:       "Answer yes to all confirmations",
+				Destination: &app.Yes,
+			},
+			&cli.BoolFlag{
+				Name:        "include-not-opted-in",
+				Usage:       "Show regions not opted in to the account in the region selection",
+				Destination: &app.IncludeNotOptedIn,
+			},
 		},
 	}
 
 	app.Cli.Version = version
 	app.Cli.Action = app.getAction()
+	app.Cli.Commands = []*cli.Command{
+		{
+			Name:   "audit",
+			Usage:  "Audit environment variables of the functions for secret-like values and KMS key usage. Values are never printed.",
+			Action: app.getAuditAction(),
+		},
+		{
+			Name:   "graviton",
+			Usage:  "Report x86_64 functions whose runtime and layers support arm64 (Graviton), grouped by region.",
+			Action: app.getGravitonAction(),
+		},
+		{
+			Name:  "owners",
+			Usage: "Group the functions by owner with the counts of deprecated runtimes, and write a file of the functions per owner.",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:        "owner-tag",
+					Usage:       "Tag key of the owners (e.g. team). Set an empty value not to list tags",
+					Value:       "owner",
+					Destination: &app.OwnerTag,
+				},
+				&cli.StringFlag{
+					Name:        "owner-map",
+					Usage:       "JSON or YAML file mapping function name prefixes to owners, for functions without the tag",
+					Destination: &app.OwnerMap,
+				},
+				&cli.StringFlag{
+					Name:        "output-dir",
+					Usage:       "Directory to write a file per owner to",
+					Value:       "owners",
+					Destination: &app.OwnerOutputDir,
+				},
+			},
+			Action: app.getOwnersAction(),
+		},
+	}
 	app.Cli.HideHelpCommand = true
 
 	return &app
@@ -70,40 +286,186 @@ func (a *App) Run(ctx context.Context) error {
 	return a.Cli.RunContext(ctx, os.Args)
 }
 
+func (a *App) getFormat() string {
+	if a.Format != "" {
+		return a.Format
+	}
+	if a.OutputFilePath != "" && a.OutputFilePath != io.StdoutFilePath {
+		return io.FormatCSV
+	}
+	return io.FormatTable
+}
+
+// getModifiedRange returns the range of last modified times to filter functions by.
+// If both --modified-before and --older-than are specified, the earlier one is used.
+func (a *App) getModifiedRange(now time.Time) (modifiedBefore time.Time, modifiedAfter time.Time, err error) {
+	if a.ModifiedBefore != "" {
+		modifiedBefore, err = action.ParseDate(a.ModifiedBefore)
+		if err != nil {
+			return modifiedBefore, modifiedAfter, err
+		}
+	}
+	if a.OlderThan != "" {
+		age, err := action.ParseAge(a.OlderThan)
+		if err != nil {
+			return modifiedBefore, modifiedAfter, err
+		}
+		threshold := now.Add(-age)
+		if modifiedBefore.IsZero() || threshold.Before(modifiedBefore) {
+			modifiedBefore = threshold
+		}
+	}
+	if a.ModifiedAfter != "" {
+		modifiedAfter, err = action.ParseDate(a.ModifiedAfter)
+		if err != nil {
+			return modifiedBefore, modifiedAfter, err
+		}
+	}
+	return modifiedBefore, modifiedAfter, nil
+}
+
+// getRuntimeExpressions returns the expressions of --runtime and --runtime-family. If empty, runtime values are selected by the checkboxes.
+func (a *App) getRuntimeExpressions() ([]*runtimeversion.Expression, error) {
+	expressions, err := runtimeversion.ParseExpressions(a.RuntimeExpression)
+	if err != nil {
+		return expressions, err
+	}
+	if a.RuntimeFamily == "" {
+		return expressions, nil
+	}
+	for _, family := range strings.Split(a.RuntimeFamily, ",") {
+		expression, err := runtimeversion.ParseExpression(family)
+		if err != nil {
+			return expressions, err
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+func (a *App) getVPCAttachment() (action.VPCAttachment, error) {
+	switch {
+	case a.InVPC && a.NoVPC:
+		return action.VPCAttachmentAny, fmt.Errorf("--in-vpc and --no-vpc cannot be specified at the same time")
+	case a.InVPC:
+		return action.VPCAttachmentAttached, nil
+	case a.NoVPC:
+		return action.VPCAttachmentDetached, nil
+	default:
+		return action.VPCAttachmentAny, nil
+	}
+}
+
 func (a *App) getAction() func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		cfg, err := client.LoadAWSConfig(c.Context, a.DefaultRegion, a.Profile)
+		if err := io.ValidateFormat(a.getFormat()); err != nil {
+			return err
+		}
+		var (
+			invocationsPeriod time.Duration
+			err               error
+		)
+		if a.WithInvocations != "" {
+			invocationsPeriod, err = action.ParseAge(a.WithInvocations)
+			if err != nil {
+				return err
+			}
+			// a zero period has no metrics to fetch
+			if invocationsPeriod <= 0 {
+				return fmt.Errorf("invalid period for --with-invocations: %q, must be longer than zero such as 30d", a.WithInvocations)
+			}
+		}
+
+		missingConfigs, err := action.ParseMissingConfigs(a.Missing)
 		if err != nil {
 			return err
 		}
 
-		lambdaClient := client.NewLambda(
-			lambda.NewFromConfig(cfg, func(o *lambda.Options) {
-				o.RetryMaxAttempts = SDKRetryMaxAttempts
-				o.RetryMode = aws.RetryModeStandard
-			}),
-		)
+		functionList, cfg, continuation, err := a.searchFunctions(c, false)
+		if err != nil {
+			return err
+		}
+		if !continuation {
+			return nil
+		}
 
-		ec2Client := client.NewEC2(
-			ec2.NewFromConfig(cfg, func(o *ec2.Options) {
-				o.RetryMaxAttempts = SDKRetryMaxAttempts
-				o.RetryMode = aws.RetryModeStandard
-			}),
-		)
+		lambdaClient := a.newLambdaClient(cfg)
 
-		getAllRegionsAndRuntimeInput := &action.GetAllRegionsAndRuntimeInput{
-			Ctx:           c.Context,
-			EC2:           ec2Client,
-			Lambda:        lambdaClient,
-			DefaultRegion: a.DefaultRegion,
+		columns := io.GetDefaultColumns()
+		if a.WithConfig || len(missingConfigs) > 0 {
+			// code signing configs need an API call per function, so they are fetched only if needed
+			withCodeSigning := a.WithConfig || action.NeedsCodeSigningConfigs(missingConfigs)
+			if withCodeSigning {
+				enrichCodeSigningConfigsInput := &action.EnrichCodeSigningConfigsInput{
+					Ctx:       c.Context,
+					Functions: functionList,
+					Lambda:    lambdaClient,
+				}
+				if err := action.EnrichCodeSigningConfigs(enrichCodeSigningConfigsInput); err != nil {
+					return err
+				}
+			}
+			if len(missingConfigs) > 0 {
+				functionList = action.FilterMissingConfigs(functionList, missingConfigs)
+			}
+			columns = append(columns, io.GetConfigColumns(withCodeSigning)...)
 		}
-		allRegions, allRuntime, err := action.GetAllRegionsAndRuntime(getAllRegionsAndRuntimeInput)
-		if err != nil {
+		if a.WithURL || a.PublicOnly {
+			enrichFunctionURLsInput := &action.EnrichFunctionURLsInput{
+				Ctx:       c.Context,
+				Functions: functionList,
+				Lambda:    lambdaClient,
+			}
+			if err := action.EnrichFunctionURLs(enrichFunctionURLsInput); err != nil {
+				return err
+			}
+			if a.PublicOnly {
+				functionList = action.FilterPublicFunctions(functionList)
+			}
+			columns = append(columns, io.GetFunctionURLColumns()...)
+		}
+		if a.WithVPC || a.InVPC {
+			columns = append(columns, io.GetVPCColumns()...)
+		}
+		if invocationsPeriod > 0 {
+			enrichInvocationsInput := &action.EnrichInvocationsInput{
+				Ctx:        c.Context,
+				Functions:  functionList,
+				Period:     invocationsPeriod,
+				CloudWatch: a.newCloudWatchClient(cfg),
+			}
+			if err := action.EnrichInvocations(enrichInvocationsInput); err != nil {
+				return err
+			}
+			columns = append(columns, io.GetInvocationColumns()...)
+		}
+		if a.WithTriggers {
+			enrichTriggersInput := &action.EnrichTriggersInput{
+				Ctx:       c.Context,
+				Functions: functionList,
+				Lambda:    lambdaClient,
+			}
+			if err := action.EnrichTriggers(enrichTriggersInput); err != nil {
+				return err
+			}
+			columns = append(columns, io.GetTriggerColumns()...)
+		}
+
+		if err := io.OutputResult(columns, functionList, a.getFormat(), a.OutputFilePath); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func (a *App) getAuditAction() func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if err := io.ValidateFormat(a.getFormat()); err != nil {
 			return err
 		}
 
-		regionsLabel := []string{"Select regions you want to search."}
-		targetRegions, continuation, err := io.GetCheckboxes(regionsLabel, allRegions)
+		functionList, _, continuation, err := a.searchFunctions(c, true)
 		if err != nil {
 			return err
 		}
@@ -111,8 +473,28 @@ func (a *App) getAction() func(c *cli.Context) error {
 			return nil
 		}
 
-		runtimeLabel := []string{"Select runtime values you want to search."}
-		targetRuntime, continuation, err := io.GetCheckboxes(runtimeLabel, allRuntime)
+		findingCount, functionCount := action.CountEnvFindings(functionList)
+		if findingCount > 0 {
+			io.Logger.Warn().Msgf("%d secret-like environment variables found in %d functions.", findingCount, functionCount)
+		} else {
+			io.Logger.Info().Msg("No secret-like environment variables found.")
+		}
+
+		if err := io.OutputResult(io.GetAuditColumns(), functionList, a.getFormat(), a.OutputFilePath); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func (a *App) getGravitonAction() func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if err := io.ValidateFormat(a.getFormat()); err != nil {
+			return err
+		}
+
+		functionList, cfg, continuation, err := a.searchFunctions(c, false)
 		if err != nil {
 			return err
 		}
@@ -120,31 +502,562 @@ func (a *App) getAction() func(c *cli.Context) error {
 			return nil
 		}
 
-		var keyword string
-		if a.FunctionNameKeyword != "" {
-			keyword = a.FunctionNameKeyword
-		} else {
-			keywordLabel := "Filter a keyword of function names(case-insensitive): "
-			keyword = io.InputKeywordForFilter(keywordLabel)
+		runtimeCatalog, err := runtimecatalog.Load(a.RuntimeCatalog)
+		if err != nil {
+			return err
 		}
 
-		createFunctionListInput := &action.CreateFunctionListInput{
-			Ctx:           c.Context,
-			TargetRegions: targetRegions,
-			TargetRuntime: targetRuntime,
-			Keyword:       keyword,
-			Lambda:        lambdaClient,
+		findGravitonCandidatesInput := &action.FindGravitonCandidatesInput{
+			Ctx:       c.Context,
+			Functions: functionList,
+			Catalog:   runtimeCatalog,
+			Lambda:    a.newLambdaClient(cfg),
 		}
-		functionList, err := action.CreateFunctionList(createFunctionListInput)
+		candidates, err := action.FindGravitonCandidates(findGravitonCandidatesInput)
 		if err != nil {
 			return err
 		}
+		// the search order of runtime and function name is kept in each region, since the sort is stable
+		action.SortFunctions(candidates, []action.SortKey{{Field: action.SortFieldRegion}}, nil)
+
+		counts := action.CountFunctionsByRegion(candidates)
+		for i, f := range candidates {
+			if i == 0 || candidates[i-1].Region != f.Region {
+				io.Logger.Info().Msgf("%s: %d Graviton candidates", f.Region, counts[f.Region])
+			}
+		}
 
-		functionHeader := types.GetLambdaFunctionDataKeys()
-		if err := io.OutputResult(functionHeader, functionList, a.CSVOutputFilePath); err != nil {
+		if err := io.OutputResult(io.GetGravitonColumns(counts), candidates, a.getFormat(), a.OutputFilePath); err != nil {
 			return err
 		}
 
 		return nil
 	}
 }
+
+func (a *App) getOwnersAction() func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		// the files are attached to tickets, so only the formats for data are supported
+		format := a.Format
+		if format == "" {
+			format = io.FormatCSV
+		}
+		if format != io.FormatCSV && format != io.FormatJSON {
+			return fmt.Errorf("unsupported format for owners: %s (use %s or %s)", format, io.FormatCSV, io.FormatJSON)
+		}
+		mapping, err := ownership.Load(a.OwnerMap)
+		if err != nil {
+			return err
+		}
+		runtimeCatalog, err := runtimecatalog.Load(a.RuntimeCatalog)
+		if err != nil {
+			return err
+		}
+
+		functionList, cfg, continuation, err := a.searchFunctions(c, false)
+		if err != nil {
+			return err
+		}
+		if !continuation {
+			return nil
+		}
+
+		for i := range functionList {
+			functionList[i].RuntimeDeprecation = runtimeCatalog.Deprecation(string(functionList[i].Runtime))
+		}
+		assignOwnersInput := &action.AssignOwnersInput{
+			Ctx:       c.Context,
+			Functions: functionList,
+			TagKey:    a.OwnerTag,
+			Mapping:   mapping,
+			Lambda:    a.newLambdaClient(cfg),
+		}
+		if err := action.AssignOwners(assignOwnersInput); err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(a.OwnerOutputDir, 0o755); err != nil {
+			return err
+		}
+		functionsByOwner := action.GroupFunctionsByOwner(functionList)
+		summaries := action.SummarizeOwners(functionList, time.Now())
+		owners := make([]string, 0, len(summaries))
+		for _, summary := range summaries {
+			owners = append(owners, summary.Owner)
+		}
+		fileNames := getOwnerFileNames(owners)
+
+		summaryCells := make([][]any, 0, len(summaries))
+		for _, summary := range summaries {
+			deprecatedRuntimes := formatDeprecatedRuntimes(summary.DeprecatedRuntimes)
+			if deprecatedRuntimes == "" {
+				io.Logger.Info().Msgf("%s: %d functions, %d on deprecated runtimes", summary.Owner, summary.Functions, summary.DeprecatedFunctions)
+			} else {
+				io.Logger.Info().Msgf("%s: %d functions, %d on deprecated runtimes (%s)", summary.Owner, summary.Functions, summary.DeprecatedFunctions, deprecatedRuntimes)
+			}
+
+			fileName := fileNames[summary.Owner] + "." + format
+			if err := io.OutputResult(io.GetOwnerColumns(), functionsByOwner[summary.Owner], format, filepath.Join(a.OwnerOutputDir, fileName)); err != nil {
+				return err
+			}
+			summaryCells = append(summaryCells, []any{
+				summary.Owner,
+				summary.Functions,
+				summary.DeprecatedFunctions,
+				deprecatedRuntimes,
+				fileName,
+			})
+		}
+
+		summaryHeader := []string{"Owner", "Functions", "DeprecatedFunctions", "DeprecatedRuntimes", "File"}
+		summaryFilePath := filepath.Join(a.OwnerOutputDir, ownerSummaryFileName+"."+format)
+		if err := io.OutputCells(summaryHeader, summaryCells, format, summaryFilePath); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// formatDeprecatedRuntimes returns the counts by deprecated runtime value, e.g. "nodejs16.x: 2, python3.8: 1".
+func formatDeprecatedRuntimes(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+	runtimes := make([]string, 0, len(counts))
+	for runtime := range counts {
+		runtimes = append(runtimes, runtime)
+	}
+	sort.Slice(runtimes, func(i, j int) bool {
+		return runtimeversion.Less(runtimes[i], runtimes[j])
+	})
+	descriptions := make([]string, 0, len(runtimes))
+	for _, runtime := range runtimes {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %d", runtime, counts[runtime]))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// ownerSummaryFileName is the name of the summary file of the owners, without the extension.
+const ownerSummaryFileName = "summary"
+
+// getOwnerFileNames returns the file names of the owners without the extension. Owners whose names are
+// the same after replacing the characters not usable in file names, such as "org/team" and "org_team",
+// or only in case, get suffixes by the order, such as "org_team" and "org_team-2". So does the owner
+// named as the summary file.
+func getOwnerFileNames(owners []string) map[string]string {
+	fileNames := make(map[string]string, len(owners))
+	// compared in lower case for case-insensitive file systems
+	used := map[string]struct{}{ownerSummaryFileName: {}}
+	for _, owner := range owners {
+		base := getOwnerFileName(owner)
+		fileName := base
+		for i := 2; ; i++ {
+			if _, ok := used[strings.ToLower(fileName)]; !ok {
+				break
+			}
+			fileName = fmt.Sprintf("%s-%d", base, i)
+		}
+		if fileName != base {
+			io.Logger.Warn().Msgf("The file of %s is named %s, as %s is used by another owner or the summary.", owner, fileName, base)
+		}
+		used[strings.ToLower(fileName)] = struct{}{}
+		fileNames[owner] = fileName
+	}
+	return fileNames
+}
+
+// getOwnerFileName returns the owner usable as a file name, replacing the characters other than letters,
+// digits, dots, hyphens and underscores, such as "/" in "org/team".
+func getOwnerFileName(owner string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, owner)
+}
+
+// searchFunctions lets the user select regions and runtime, and searches functions by them and the flags.
+// The environment variables of the functions are audited if audit is true.
+// It returns false as continuation if the user cancels the selection.
+func (a *App) searchFunctions(c *cli.Context, audit bool) (functionList []types.LambdaFunctionData, cfg aws.Config, continuation bool, err error) {
+	sortKeys, err := action.ParseSortKeys(a.SortBy)
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+	modifiedBefore, modifiedAfter, err := a.getModifiedRange(time.Now())
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+	vpc, err := a.getVPCAttachment()
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+	if a.Architecture != "" {
+		if err := action.ValidateArchitecture(a.Architecture); err != nil {
+			return functionList, cfg, false, err
+		}
+	}
+	runtimeExpressions, err := a.getRuntimeExpressions()
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+	query, err := action.ParseQuery(a.Query)
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+	runtimeCatalog, err := runtimecatalog.Load(a.RuntimeCatalog)
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+	statePath, remembered := a.loadSelection()
+	a.setInputHistory(remembered.History)
+
+	cfg, err = client.LoadAWSConfigWithPartition(c.Context, a.DefaultRegion, a.Profile, a.Partition)
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+	io.Logger.Debug().Msgf("region: %s, partition: %s", cfg.Region, client.GetPartition(cfg.Region))
+
+	lambdaClient := a.newLambdaClient(cfg)
+	prompter := a.getPrompter()
+
+	getAllRegionsAndRuntimeInput := &action.GetAllRegionsAndRuntimeInput{
+		Ctx:           c.Context,
+		EC2:           a.newEC2Client(cfg),
+		Lambda:        lambdaClient,
+		DefaultRegion: cfg.Region,
+		Catalog:       runtimeCatalog,
+	}
+	allRegions, allRuntime, err := action.GetAllRegionsAndRuntime(getAllRegionsAndRuntimeInput)
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+
+	if !a.IncludeNotOptedIn {
+		var disabledRegions []client.Region
+		allRegions, disabledRegions = action.SplitRegionsByOptIn(allRegions)
+		if len(disabledRegions) > 0 {
+			io.Logger.Info().Msgf("%d regions not opted in are hidden. Use --include-not-opted-in to show them.", len(disabledRegions))
+		}
+	}
+
+	// the regions are listed before the selection to show the number of functions, and the listings are reused
+	scans := map[string]action.RegionScan{}
+	if a.ScanRegions {
+		enabledRegions, _ := action.SplitRegionsByOptIn(allRegions)
+		scanRegions := make([]string, 0, len(enabledRegions))
+		for _, region := range enabledRegions {
+			scanRegions = append(scanRegions, region.Name)
+		}
+		io.Logger.Info().Msgf("Scanning functions in %d regions...", len(scanRegions))
+		scans = action.ScanRegions(&action.ScanRegionsInput{
+			Ctx:           c.Context,
+			TargetRegions: scanRegions,
+			Lambda:        lambdaClient,
+		})
+	}
+
+	regionNames := make([]string, 0, len(allRegions))
+	regionLabels := make([]string, 0, len(allRegions))
+	regionsByName := make(map[string]client.Region, len(allRegions))
+	for _, region := range allRegions {
+		regionNames = append(regionNames, region.Name)
+		regionLabels = append(regionLabels, getRegionLabel(region, scans))
+		regionsByName[region.Name] = region
+	}
+
+	regionsLabel := []string{"Select regions you want to search."}
+	selectedRegionNames, continuation, err := io.GetCheckboxes(prompter, regionsLabel, regionNames, regionLabels, remembered.Regions)
+	if err != nil || !continuation {
+		return functionList, cfg, false, err
+	}
+
+	selectedRegions := make([]client.Region, 0, len(selectedRegionNames))
+	for _, name := range selectedRegionNames {
+		selectedRegions = append(selectedRegions, regionsByName[name])
+	}
+	enabledRegions, disabledRegions := action.SplitRegionsByOptIn(selectedRegions)
+	for _, region := range disabledRegions {
+		io.Logger.Warn().Msgf("%s is skipped because it is not opted in.", region.Name)
+	}
+	if len(enabledRegions) == 0 {
+		io.Logger.Warn().Msg("No regions to search.")
+		return functionList, cfg, false, nil
+	}
+
+	targetRegions := make([]string, 0, len(enabledRegions))
+	listings := make(map[string][]lambdaTypes.FunctionConfiguration, len(enabledRegions))
+	regionsToList := []string{}
+	for _, region := range enabledRegions {
+		targetRegions = append(targetRegions, region.Name)
+		if scan, ok := scans[region.Name]; ok && scan.Err == nil {
+			listings[region.Name] = scan.Functions
+		} else {
+			regionsToList = append(regionsToList, region.Name)
+		}
+	}
+
+	// the functions are listed before the runtime selection, to discover runtime values unknown to the catalog
+	// and to count the functions. Runtime expressions are matched against the catalog without them,
+	// unless only present runtime values are needed.
+	if len(runtimeExpressions) == 0 || a.PresentOnly {
+		listedFunctions, err := action.ListFunctionsByRegion(&action.ListFunctionsByRegionInput{
+			Ctx:           c.Context,
+			TargetRegions: regionsToList,
+			Lambda:        lambdaClient,
+		})
+		if err != nil {
+			return functionList, cfg, false, err
+		}
+		for region, functions := range listedFunctions {
+			listings[region] = functions
+		}
+	}
+	unknownRuntime := runtimeCatalog.Unknown(action.DiscoverRuntimes(listings))
+	if len(unknownRuntime) > 0 {
+		io.Logger.Info().Msgf("Runtime values unknown to this build are found: %s", strings.Join(unknownRuntime, ", "))
+		allRuntime = append(allRuntime, unknownRuntime...)
+		sort.Slice(allRuntime, func(i, j int) bool {
+			return runtimeversion.Less(allRuntime[i], allRuntime[j])
+		})
+	}
+	runtimeCounts := action.CountFunctionsByRuntime(listings)
+	if a.PresentOnly {
+		allRuntime = filterPresentRuntime(allRuntime, runtimeCounts)
+		if len(allRuntime) == 0 {
+			io.Logger.Warn().Msg("No functions with runtime values in the selected regions.")
+			return functionList, cfg, false, nil
+		}
+	}
+
+	// only the values selected in the prompts are remembered, keeping the others remembered before
+	selection := state.Selection{
+		Regions:  targetRegions,
+		Runtimes: remembered.Runtimes,
+		Keyword:  remembered.Keyword,
+	}
+
+	var targetRuntime []string
+	if len(runtimeExpressions) > 0 {
+		targetRuntime = runtimeversion.Filter(allRuntime, runtimeExpressions)
+		if len(targetRuntime) == 0 {
+			io.Logger.Warn().Msg("No runtime values match the expressions.")
+			return functionList, cfg, false, nil
+		}
+		io.Logger.Info().Msgf("Runtime values: %s", strings.Join(targetRuntime, ", "))
+	} else {
+		runtimeLabels := make([]string, 0, len(allRuntime))
+		for _, runtime := range allRuntime {
+			runtimeLabels = append(runtimeLabels, a.getRuntimeLabel(runtime, runtimeCatalog, runtimeCounts))
+		}
+
+		runtimeLabel := []string{"Select runtime values you want to search."}
+		targetRuntime, continuation, err = io.GetCheckboxes(prompter, runtimeLabel, allRuntime, runtimeLabels, remembered.Runtimes)
+		if err != nil || !continuation {
+			return functionList, cfg, false, err
+		}
+		selection.Runtimes = targetRuntime
+	}
+
+	// the keyword prompt takes a plain keyword or a search query, and a keyword by -k is used as it is
+	keyword := a.FunctionNameKeyword
+	queryString := a.Query
+	if a.FunctionNameKeyword == "" && a.Query == "" {
+		query, queryString, continuation, err = promptQuery(prompter, remembered.Keyword)
+		if err != nil || !continuation {
+			return functionList, cfg, false, err
+		}
+		if query == nil {
+			keyword = queryString
+		}
+		selection.Keyword = queryString
+	}
+	selection.History = a.getInputHistory(remembered.History)
+
+	a.saveSelection(statePath, selection)
+
+	createFunctionListInput := &action.CreateFunctionListInput{
+		Ctx:            c.Context,
+		TargetRegions:  targetRegions,
+		TargetRuntime:  targetRuntime,
+		Keyword:        keyword,
+		ModifiedBefore: modifiedBefore,
+		ModifiedAfter:  modifiedAfter,
+		VPC:            vpc,
+		Architecture:   a.Architecture,
+		SortKeys:       sortKeys,
+		Listings:       listings,
+		Query:          query,
+		Audit:          audit,
+		Lambda:         lambdaClient,
+	}
+	functionList, err = action.CreateFunctionList(createFunctionListInput)
+	if err != nil {
+		return functionList, cfg, false, err
+	}
+
+	return functionList, cfg, true, nil
+}
+
+// promptQuery asks a keyword or a search query until it is valid, keeping the invalid one to be fixed.
+// The query is nil if the input is a plain keyword without fields or operators, to match a part of
+// the function name as it is, e.g. "-prod" or "my func".
+func promptQuery(prompter io.Prompter, value string) (query *action.Query, queryString string, continuation bool, err error) {
+	label := "Filter functions by a keyword or a query (e.g. name:api runtime:python3.* -name:test): "
+	for {
+		queryString, continuation, err = prompter.Input(label, value)
+		if err != nil || !continuation {
+			return query, queryString, continuation, err
+		}
+		if !action.IsQuery(queryString) {
+			return nil, queryString, true, nil
+		}
+		query, err = action.ParseQuery(queryString)
+		if err == nil {
+			return query, queryString, true, nil
+		}
+		io.Logger.Warn().Msg(err.Error())
+		value = queryString
+	}
+}
+
+// getPrompter returns the prompter, answering yes to all confirmations if --yes is specified.
+func (a *App) getPrompter() io.Prompter {
+	if a.Yes {
+		return io.AssumeYes(a.Prompter)
+	}
+	return a.Prompter
+}
+
+// setInputHistory lets the input prompts recall the history, if the prompter keeps one.
+func (a *App) setInputHistory(history []string) {
+	if p, ok := a.Prompter.(*io.TUIPrompter); ok && history != nil {
+		p.History = history
+	}
+}
+
+// getInputHistory returns the history of the input prompts, or the given one if the prompter keeps none.
+func (a *App) getInputHistory(history []string) []string {
+	if p, ok := a.Prompter.(*io.TUIPrompter); ok {
+		return p.History
+	}
+	return history
+}
+
+// loadSelection returns the path of the state file and the last selection for the profile.
+// The path is empty if the selections are not remembered by --no-remember or a failure.
+func (a *App) loadSelection() (string, state.Selection) {
+	if a.NoRemember {
+		return "", state.Selection{}
+	}
+
+	path, err := state.DefaultPath()
+	if err != nil {
+		io.Logger.Warn().Msgf("The last selections are not remembered: %v", err)
+		return "", state.Selection{}
+	}
+	selection, err := state.Load(path, a.getProfileName())
+	if err != nil {
+		io.Logger.Warn().Msgf("The last selections are not remembered: %v", err)
+		return "", state.Selection{}
+	}
+	return path, selection
+}
+
+// saveSelection remembers the selection for the next run. A failure does not stop the search.
+func (a *App) saveSelection(path string, selection state.Selection) {
+	if path == "" {
+		return
+	}
+	if err := state.Save(path, a.getProfileName(), selection); err != nil {
+		io.Logger.Warn().Msgf("The selections are not remembered: %v", err)
+	}
+}
+
+// getProfileName returns the name of the profile that the selections are remembered for.
+func (a *App) getProfileName() string {
+	if a.Profile != "" {
+		return a.Profile
+	}
+	if profile := os.Getenv("AWS_PROFILE"); profile != "" {
+		return profile
+	}
+	return "default"
+}
+
+func newEC2Client(cfg aws.Config) client.EC2Client {
+	return client.NewEC2(
+		ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+			o.RetryMaxAttempts = SDKRetryMaxAttempts
+			o.RetryMode = aws.RetryModeStandard
+		}),
+	)
+}
+
+func newCloudWatchClient(cfg aws.Config) client.CloudWatchClient {
+	return client.NewCloudWatch(
+		cloudwatch.NewFromConfig(cfg, func(o *cloudwatch.Options) {
+			o.RetryMaxAttempts = SDKRetryMaxAttempts
+			o.RetryMode = aws.RetryModeStandard
+		}),
+	)
+}
+
+func newLambdaClient(cfg aws.Config) client.LambdaClient {
+	return client.NewLambda(
+		lambda.NewFromConfig(cfg, func(o *lambda.Options) {
+			o.RetryMaxAttempts = SDKRetryMaxAttempts
+			o.RetryMode = aws.RetryModeStandard
+		}),
+	)
+}
+
+// getRuntimeLabel returns the label of the runtime value in the selection, with the number of functions
+// if --present-only is specified.
+func (a *App) getRuntimeLabel(runtime string, runtimeCatalog *runtimecatalog.Catalog, runtimeCounts map[string]int) string {
+	annotations := []string{}
+	if a.PresentOnly {
+		annotations = append(annotations, strconv.Itoa(runtimeCounts[runtime]))
+	}
+	if !runtimeCatalog.Contains(runtime) {
+		annotations = append(annotations, "unknown to this build")
+	}
+	if len(annotations) == 0 {
+		return runtime
+	}
+	return fmt.Sprintf("%s (%s)", runtime, strings.Join(annotations, ", "))
+}
+
+func filterPresentRuntime(runtimeList []string, runtimeCounts map[string]int) []string {
+	present := []string{}
+	for _, runtime := range runtimeList {
+		if runtimeCounts[runtime] > 0 {
+			present = append(present, runtime)
+		}
+	}
+	return present
+}
+
+// getRegionLabel returns the label of the region in the selection, with the result of the scan if scanned.
+func getRegionLabel(region client.Region, scans map[string]action.RegionScan) string {
+	label := fmt.Sprintf("%s (%s, %s)", region.Name, region.Partition, region.OptInStatus)
+	if region.OptInStatus == client.RegionOptInNotRequired {
+		label = fmt.Sprintf("%s (%s)", region.Name, region.Partition)
+	}
+
+	scan, ok := scans[region.Name]
+	switch {
+	case !ok:
+		return label
+	case scan.TimedOut:
+		return fmt.Sprintf("%s - timed out", label)
+	case scan.Err != nil:
+		return fmt.Sprintf("%s - failed", label)
+	default:
+		return fmt.Sprintf("%s - %d functions in %s", label, len(scan.Functions), scan.Elapsed.Round(100*time.Millisecond))
+	}
+}
@@ -0,0 +1,458 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-to-k/lamver/internal/io"
+	"github.com/go-to-k/lamver/internal/state"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"go.uber.org/mock/gomock"
+)
+
+// scriptedPrompter answers the prompts in order, and fails the test on an unexpected prompt.
+type scriptedPrompter struct {
+	t          *testing.T
+	checkboxes [][]string
+	inputs     []string
+	// confirms are "y", "n" or "cancel"
+	confirms []string
+}
+
+var _ io.Prompter = (*scriptedPrompter)(nil)
+
+func (p *scriptedPrompter) Checkboxes(headers []string, opts []string, labels []string, selected []string) ([]string, bool, error) {
+	if len(p.checkboxes) == 0 {
+		p.t.Fatalf("unexpected checkboxes: %v", headers)
+	}
+	checkboxes := p.checkboxes[0]
+	p.checkboxes = p.checkboxes[1:]
+	// nil means canceled
+	return checkboxes, checkboxes != nil, nil
+}
+
+func (p *scriptedPrompter) Input(label string, value string) (string, bool, error) {
+	if len(p.inputs) == 0 {
+		p.t.Fatalf("unexpected input: %s", label)
+	}
+	input := p.inputs[0]
+	p.inputs = p.inputs[1:]
+	return input, true, nil
+}
+
+func (p *scriptedPrompter) Confirm(label string) (bool, bool, error) {
+	if len(p.confirms) == 0 {
+		p.t.Fatalf("unexpected confirmation: %s", label)
+	}
+	confirm := p.confirms[0]
+	p.confirms = p.confirms[1:]
+	return confirm == "y", confirm != "cancel", nil
+}
+
+func (p *scriptedPrompter) assertDone() {
+	if len(p.checkboxes) > 0 || len(p.inputs) > 0 || len(p.confirms) > 0 {
+		p.t.Errorf("prompts not asked: checkboxes %v, inputs %v, confirms %v", p.checkboxes, p.inputs, p.confirms)
+	}
+}
+
+func newTestApp(t *testing.T, prompter io.Prompter, ec2Client client.EC2Client, lambdaClient client.LambdaClient) *App {
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_PROFILE", "")
+
+	app := NewApp("test")
+	app.Prompter = prompter
+	app.newEC2Client = func(aws.Config) client.EC2Client { return ec2Client }
+	app.newLambdaClient = func(aws.Config) client.LambdaClient { return lambdaClient }
+	return app
+}
+
+func TestApp_getAction(t *testing.T) {
+	functions := []lambdaTypes.FunctionConfiguration{
+		{
+			FunctionName: aws.String("api-handler"),
+			Runtime:      lambdaTypes.RuntimeNodejs20x,
+			LastModified: aws.String("2024-01-01T00:00:00.000+0000"),
+		},
+		{
+			FunctionName: aws.String("batch-job"),
+			Runtime:      lambdaTypes.RuntimeNodejs20x,
+			LastModified: aws.String("2024-01-01T00:00:00.000+0000"),
+		},
+		{
+			FunctionName: aws.String("api-legacy"),
+			Runtime:      lambdaTypes.RuntimePython39,
+			LastModified: aws.String("2024-01-01T00:00:00.000+0000"),
+		},
+	}
+
+	tests := []struct {
+		name                      string
+		flags                     []string
+		prompter                  *scriptedPrompter
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		wantOutput                []string
+		wantNoOutput              bool
+	}{
+		{
+			name:  "search with the selections",
+			flags: []string{},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{{"us-east-1"}, {"nodejs20.x"}},
+				inputs:     []string{"api"},
+				confirms:   []string{"y", "y"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(functions, nil)
+			},
+			wantOutput: []string{"api-handler"},
+		},
+		{
+			name:  "search without confirmations by --yes",
+			flags: []string{"--yes", "-k", "legacy"},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{{"us-east-1"}, {"nodejs20.x", "python3.9"}},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(functions, nil)
+			},
+			wantOutput: []string{"api-legacy"},
+		},
+		{
+			name:  "search runtime values by expressions without the checkboxes",
+			flags: []string{"--yes", "--runtime", "nodejs>=20"},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{{"us-east-1"}},
+				inputs:     []string{""},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(functions, nil)
+			},
+			wantOutput: []string{"api-handler", "batch-job"},
+		},
+		{
+			name:  "search by a query instead of the keyword prompt",
+			flags: []string{"--yes", "--query", "name:api-* -name:legacy"},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{{"us-east-1"}, {"nodejs20.x", "python3.9"}},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(functions, nil)
+			},
+			wantOutput: []string{"api-handler"},
+		},
+		{
+			name:  "search by a query in the keyword prompt asked again for an invalid one",
+			flags: []string{"--yes"},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{{"us-east-1"}, {"nodejs20.x", "python3.9"}},
+				inputs:     []string{"nmae:api", "api runtime:python*"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(functions, nil)
+			},
+			wantOutput: []string{"api-legacy"},
+		},
+		{
+			name:  "search by a plain keyword starting with a hyphen in the keyword prompt",
+			flags: []string{"--yes"},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{{"us-east-1"}, {"nodejs20.x", "python3.9"}},
+				inputs:     []string{"-legacy"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(functions, nil)
+			},
+			wantOutput: []string{"api-legacy"},
+		},
+		{
+			name:  "finish by canceling the confirmation to finish",
+			flags: []string{},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{{}},
+				confirms:   []string{"cancel"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {},
+			wantNoOutput:              true,
+		},
+		{
+			name:  "finish by canceling the region selection",
+			flags: []string{},
+			prompter: &scriptedPrompter{
+				checkboxes: [][]string{nil},
+				confirms:   []string{"y"},
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {},
+			wantNoOutput:              true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ec2ClientMock := client.NewMockEC2Client(ctrl)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			ec2ClientMock.EXPECT().DescribeRegions(gomock.Any()).Return(
+				[]client.Region{
+					{Name: "ap-northeast-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+					{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+				}, nil,
+			)
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			tt.prompter.t = t
+			app := newTestApp(t, tt.prompter, ec2ClientMock, lambdaClientMock)
+
+			outputFilePath := filepath.Join(t.TempDir(), "result.csv")
+			args := append([]string{"lamver", "-r", "us-east-1", "--no-remember", "-o", outputFilePath}, tt.flags...)
+			if err := app.Cli.RunContext(context.Background(), args); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			tt.prompter.assertDone()
+
+			output, err := os.ReadFile(outputFilePath)
+			if tt.wantNoOutput {
+				if err == nil {
+					t.Errorf("output = %s, want no output", output)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read the output: %v", err)
+			}
+
+			var gotNames []string
+			for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n")[1:] {
+				gotNames = append(gotNames, strings.Split(line, ",")[2])
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantOutput) {
+				t.Errorf("functions = %v, want %v", gotNames, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestApp_getActionWithInvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := newTestApp(t, &scriptedPrompter{t: t}, client.NewMockEC2Client(ctrl), client.NewMockLambdaClient(ctrl))
+
+	args := []string{"lamver", "-r", "us-east-1", "--no-remember", "--query", "modified:2023-06-01"}
+	err := app.Cli.RunContext(context.Background(), args)
+	want := `use <, <=, > or >= for modified in query term "modified:2023-06-01" (e.g. modified<2023-06-01)`
+	if err == nil || err.Error() != want {
+		t.Errorf("Run() error = %v, want %s", err, want)
+	}
+}
+
+func TestApp_getActionWithZeroInvocationsPeriod(t *testing.T) {
+	for _, period := range []string{"0d", "0h"} {
+		t.Run(period, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			app := newTestApp(t, &scriptedPrompter{t: t}, client.NewMockEC2Client(ctrl), client.NewMockLambdaClient(ctrl))
+
+			args := []string{"lamver", "-r", "us-east-1", "--no-remember", "--with-invocations", period}
+			err := app.Cli.RunContext(context.Background(), args)
+			want := fmt.Sprintf("invalid period for --with-invocations: %q, must be longer than zero such as 30d", period)
+			if err == nil || err.Error() != want {
+				t.Errorf("Run() error = %v, want %s", err, want)
+			}
+		})
+	}
+}
+
+func TestApp_getActionRemembersPromptedValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ec2ClientMock := client.NewMockEC2Client(ctrl)
+	lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+	ec2ClientMock.EXPECT().DescribeRegions(gomock.Any()).Return(
+		[]client.Region{
+			{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+		}, nil,
+	)
+	lambdaClientMock.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+		[]lambdaTypes.FunctionConfiguration{}, nil,
+	)
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	statePath, err := state.DefaultPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remembered := state.Selection{Regions: []string{"ap-northeast-1"}, Runtimes: []string{"python3.9"}, Keyword: "legacy"}
+	if err := state.Save(statePath, "default", remembered); err != nil {
+		t.Fatal(err)
+	}
+
+	prompter := &scriptedPrompter{t: t, checkboxes: [][]string{{"us-east-1"}}}
+	app := newTestApp(t, prompter, ec2ClientMock, lambdaClientMock)
+
+	args := []string{"lamver", "-r", "us-east-1", "--yes", "--runtime", "nodejs>=20", "-k", "api", "-o", filepath.Join(t.TempDir(), "result.csv")}
+	if err := app.Cli.RunContext(context.Background(), args); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	prompter.assertDone()
+
+	got, err := state.Load(statePath, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := state.Selection{Regions: []string{"us-east-1"}, Runtimes: []string{"python3.9"}, Keyword: "legacy"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("remembered = %+v, want %+v", got, want)
+	}
+}
+
+func TestApp_inputHistory(t *testing.T) {
+	history := []string{"api", "name:batch-*"}
+
+	app := NewApp("test")
+	app.setInputHistory(history)
+	if got := app.Prompter.(*io.TUIPrompter).History; !reflect.DeepEqual(got, history) {
+		t.Errorf("TUIPrompter.History = %v, want %v", got, history)
+	}
+	if got := app.getInputHistory(nil); !reflect.DeepEqual(got, history) {
+		t.Errorf("getInputHistory() = %v, want %v", got, history)
+	}
+
+	// the history is kept as it is for a prompter without history
+	app.Prompter = &scriptedPrompter{t: t}
+	app.setInputHistory([]string{"legacy"})
+	if got := app.getInputHistory(history); !reflect.DeepEqual(got, history) {
+		t.Errorf("getInputHistory() = %v, want %v", got, history)
+	}
+}
+
+func TestApp_getOwnersAction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ec2ClientMock := client.NewMockEC2Client(ctrl)
+	lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+	ec2ClientMock.EXPECT().DescribeRegions(gomock.Any()).Return(
+		[]client.Region{
+			{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired, Partition: client.PartitionAWS},
+		}, nil,
+	)
+	lambdaClientMock.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+		[]lambdaTypes.FunctionConfiguration{
+			{
+				FunctionName: aws.String("orders-api"),
+				FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:orders-api"),
+				Runtime:      lambdaTypes.RuntimeNodejs16x,
+			},
+			{
+				FunctionName: aws.String("orders-worker"),
+				FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:orders-worker"),
+				Runtime:      lambdaTypes.RuntimeNodejs16x,
+			},
+			{
+				FunctionName: aws.String("batch"),
+				FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:batch"),
+				Runtime:      lambdaTypes.RuntimeNodejs16x,
+			},
+		}, nil,
+	)
+	lambdaClientMock.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:orders-api").Return(
+		map[string]string{"team": "org/api"}, nil,
+	)
+	lambdaClientMock.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:orders-worker").Return(
+		map[string]string{"team": "org_api"}, nil,
+	)
+	lambdaClientMock.EXPECT().ListTagsWithRegion(gomock.Any(), "us-east-1", "arn:aws:lambda:us-east-1:123456789012:function:batch").Return(
+		map[string]string{}, nil,
+	)
+
+	prompter := &scriptedPrompter{
+		t:          t,
+		checkboxes: [][]string{{"us-east-1"}, {"nodejs16.x"}},
+		inputs:     []string{""},
+	}
+	app := newTestApp(t, prompter, ec2ClientMock, lambdaClientMock)
+
+	dir := t.TempDir()
+	mappingPath := filepath.Join(dir, "owners.yaml")
+	if err := os.WriteFile(mappingPath, []byte("prefixes:\n  orders-: team-orders\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	outputDir := filepath.Join(dir, "owners")
+
+	args := []string{"lamver", "-r", "us-east-1", "--no-remember", "--yes", "owners", "--owner-tag", "team", "--owner-map", mappingPath, "--output-dir", outputDir}
+	if err := app.Cli.RunContext(context.Background(), args); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	prompter.assertDone()
+
+	// "org/api" and "org_api" are the same file name, so the latter gets a suffix
+	want := map[string][]string{
+		"org_api.csv":   {"orders-api"},
+		"org_api-2.csv": {"orders-worker"},
+		"unowned.csv":   {"batch"},
+	}
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		t.Fatalf("failed to read the output directory: %v", err)
+	}
+	got := map[string][]string{}
+	for _, entry := range entries {
+		output, err := os.ReadFile(filepath.Join(outputDir, entry.Name()))
+		if err != nil {
+			t.Fatalf("failed to read the output: %v", err)
+		}
+		if entry.Name() == "summary.csv" {
+			wantSummary := "Owner,Functions,DeprecatedFunctions,DeprecatedRuntimes,File\n" +
+				"org/api,1,1,nodejs16.x: 1,org_api.csv\n" +
+				"org_api,1,1,nodejs16.x: 1,org_api-2.csv\n" +
+				"unowned,1,1,nodejs16.x: 1,unowned.csv\n"
+			if string(output) != wantSummary {
+				t.Errorf("summary = %q, want %q", output, wantSummary)
+			}
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n")[1:] {
+			got[entry.Name()] = append(got[entry.Name()], strings.Split(line, ",")[4])
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("owner files = %v, want %v", got, want)
+	}
+}
+
+func Test_getOwnerFileNames(t *testing.T) {
+	owners := []string{"org/team", "org_team", "Org_Team", "summary", "org_team-2"}
+	want := map[string]string{
+		"org/team":   "org_team",
+		"org_team":   "org_team-2",
+		"Org_Team":   "Org_Team-3",
+		"summary":    "summary-2",
+		"org_team-2": "org_team-2-2",
+	}
+	if got := getOwnerFileNames(owners); !reflect.DeepEqual(got, want) {
+		t.Errorf("getOwnerFileNames() = %v, want %v", got, want)
+	}
+}
+
+func Test_getOwnerFileName(t *testing.T) {
+	tests := []struct {
+		owner string
+		want  string
+	}{
+		{owner: "team-orders", want: "team-orders"},
+		{owner: "org/team api", want: "org_team_api"},
+		{owner: "../etc", want: ".._etc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.owner, func(t *testing.T) {
+			if got := getOwnerFileName(tt.owner); got != tt.want {
+				t.Errorf("getOwnerFileName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
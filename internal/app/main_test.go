@@ -0,0 +1,19 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-to-k/lamver/internal/io"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	fmt.Println()
+	fmt.Println("==========================================")
+	fmt.Println("============ Start Test: app =============")
+	fmt.Println("==========================================")
+	io.NewLogger(false)
+	goleak.VerifyTestMain(m)
+}
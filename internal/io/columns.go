@@ -0,0 +1,312 @@
+package io
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-to-k/lamver/internal/types"
+	"github.com/go-to-k/lamver/pkg/client"
+)
+
+// maskedValue is shown instead of the values of environment variables.
+const maskedValue = "********"
+
+// Column is a column of the output, rendered from each function.
+type Column struct {
+	Name  string
+	Value func(f *types.LambdaFunctionData) string
+	// Data returns the typed value for the structured formats, such as a number or a list. It is optional,
+	// and the value is used if nil, where an empty value is nil.
+	Data func(f *types.LambdaFunctionData) any
+	// Link returns the URL that the cell links to. It is optional.
+	Link func(f *types.LambdaFunctionData) string
+}
+
+func (c *Column) data(f *types.LambdaFunctionData) any {
+	if c.Data == nil {
+		if value := c.Value(f); value != "" {
+			return value
+		}
+		return nil
+	}
+	value := c.Data(f)
+	// a list not set is null, not an empty list
+	if list, ok := value.([]string); ok && list == nil {
+		return nil
+	}
+	return value
+}
+
+func GetDefaultColumns() []Column {
+	return []Column{
+		{
+			Name:  "Runtime",
+			Value: func(f *types.LambdaFunctionData) string { return string(f.Runtime) },
+		},
+		{
+			Name:  "Region",
+			Value: func(f *types.LambdaFunctionData) string { return f.Region },
+		},
+		{
+			Name:  "FunctionName",
+			Value: func(f *types.LambdaFunctionData) string { return f.FunctionName },
+		},
+		{
+			Name:  "LastModified",
+			Value: func(f *types.LambdaFunctionData) string { return formatTime(f.LastModified) },
+		},
+		{
+			Name:  "CodeSize",
+			Value: func(f *types.LambdaFunctionData) string { return strconv.FormatInt(f.CodeSize, 10) },
+			Data:  func(f *types.LambdaFunctionData) any { return f.CodeSize },
+		},
+		{
+			Name:  "FunctionArn",
+			Value: func(f *types.LambdaFunctionData) string { return f.FunctionArn },
+			Link:  func(f *types.LambdaFunctionData) string { return f.ConsoleURL },
+		},
+		{
+			Name:  "ConsoleURL",
+			Value: func(f *types.LambdaFunctionData) string { return f.ConsoleURL },
+			Link:  func(f *types.LambdaFunctionData) string { return f.ConsoleURL },
+		},
+	}
+}
+
+// GetInvocationColumns returns the columns of invocation activity enriched from CloudWatch.
+func GetInvocationColumns() []Column {
+	return []Column{
+		{
+			Name:  "Invocations",
+			Value: func(f *types.LambdaFunctionData) string { return strconv.FormatInt(f.Invocations, 10) },
+			Data:  func(f *types.LambdaFunctionData) any { return f.Invocations },
+		},
+		{
+			Name:  "Errors",
+			Value: func(f *types.LambdaFunctionData) string { return strconv.FormatInt(f.Errors, 10) },
+			Data:  func(f *types.LambdaFunctionData) any { return f.Errors },
+		},
+		{
+			Name:  "LastInvoked",
+			Value: func(f *types.LambdaFunctionData) string { return formatDate(f.LastInvoked) },
+		},
+	}
+}
+
+// GetTriggerColumns returns the columns of triggers enriched from event source mappings and function policies.
+func GetTriggerColumns() []Column {
+	return []Column{
+		{
+			Name:  "Triggers",
+			Value: func(f *types.LambdaFunctionData) string { return strings.Join(f.Triggers, ", ") },
+			Data:  func(f *types.LambdaFunctionData) any { return f.Triggers },
+		},
+	}
+}
+
+// GetFunctionURLColumns returns the columns of function URLs.
+func GetFunctionURLColumns() []Column {
+	return []Column{
+		{
+			Name:  "FunctionURLAuthType",
+			Value: func(f *types.LambdaFunctionData) string { return f.FunctionURLAuthType },
+		},
+	}
+}
+
+// GetVPCColumns returns the columns of the VPC attachment.
+func GetVPCColumns() []Column {
+	return []Column{
+		{
+			Name:  "VpcId",
+			Value: func(f *types.LambdaFunctionData) string { return f.VpcID },
+		},
+		{
+			Name:  "SubnetIds",
+			Value: func(f *types.LambdaFunctionData) string { return strings.Join(f.SubnetIDs, ", ") },
+			Data:  func(f *types.LambdaFunctionData) any { return f.SubnetIDs },
+		},
+		{
+			Name:  "SecurityGroupIds",
+			Value: func(f *types.LambdaFunctionData) string { return strings.Join(f.SecurityGroupIDs, ", ") },
+			Data:  func(f *types.LambdaFunctionData) any { return f.SecurityGroupIDs },
+		},
+	}
+}
+
+// GetConfigColumns returns the columns of tracing, SnapStart, logging and dead-letter queue configurations,
+// and of the code signing config if withCodeSigning is true.
+func GetConfigColumns(withCodeSigning bool) []Column {
+	columns := []Column{
+		{
+			Name:  "TracingMode",
+			Value: func(f *types.LambdaFunctionData) string { return f.TracingMode },
+		},
+		{
+			Name:  "SnapStart",
+			Value: func(f *types.LambdaFunctionData) string { return f.SnapStart },
+		},
+		{
+			Name:  "LogFormat",
+			Value: func(f *types.LambdaFunctionData) string { return f.LogFormat },
+		},
+		{
+			Name:  "LogGroup",
+			Value: func(f *types.LambdaFunctionData) string { return f.LogGroup },
+		},
+		{
+			Name:  "DeadLetterTargetArn",
+			Value: func(f *types.LambdaFunctionData) string { return f.DeadLetterTargetArn },
+		},
+	}
+	if withCodeSigning {
+		columns = append(columns, Column{
+			Name:  "CodeSigningConfigArn",
+			Value: func(f *types.LambdaFunctionData) string { return f.CodeSigningConfigArn },
+		})
+	}
+	return columns
+}
+
+// GetGravitonColumns returns the columns of the Graviton (arm64) migration report.
+// The counts are the numbers of candidates in each region.
+func GetGravitonColumns(counts map[string]int) []Column {
+	return []Column{
+		{
+			Name:  "Region",
+			Value: func(f *types.LambdaFunctionData) string { return f.Region },
+		},
+		{
+			Name:  "RegionCandidates",
+			Value: func(f *types.LambdaFunctionData) string { return strconv.Itoa(counts[f.Region]) },
+			Data:  func(f *types.LambdaFunctionData) any { return counts[f.Region] },
+		},
+		{
+			Name:  "Runtime",
+			Value: func(f *types.LambdaFunctionData) string { return string(f.Runtime) },
+		},
+		{
+			Name:  "FunctionName",
+			Value: func(f *types.LambdaFunctionData) string { return f.FunctionName },
+			Link:  func(f *types.LambdaFunctionData) string { return f.ConsoleURL },
+		},
+		{
+			Name:  "Layers",
+			Value: func(f *types.LambdaFunctionData) string { return strings.Join(f.Layers, ", ") },
+			Data:  func(f *types.LambdaFunctionData) any { return f.Layers },
+		},
+		{
+			Name:  "CodeSize",
+			Value: func(f *types.LambdaFunctionData) string { return strconv.FormatInt(f.CodeSize, 10) },
+			Data:  func(f *types.LambdaFunctionData) any { return f.CodeSize },
+		},
+	}
+}
+
+// GetOwnerColumns returns the columns of the ownership report.
+func GetOwnerColumns() []Column {
+	return []Column{
+		{
+			Name:  "Owner",
+			Value: func(f *types.LambdaFunctionData) string { return f.Owner },
+		},
+		{
+			Name:  "Runtime",
+			Value: func(f *types.LambdaFunctionData) string { return string(f.Runtime) },
+		},
+		{
+			Name:  "RuntimeDeprecation",
+			Value: func(f *types.LambdaFunctionData) string { return formatDate(f.RuntimeDeprecation) },
+		},
+		{
+			Name:  "Region",
+			Value: func(f *types.LambdaFunctionData) string { return f.Region },
+		},
+		{
+			Name:  "FunctionName",
+			Value: func(f *types.LambdaFunctionData) string { return f.FunctionName },
+			Link:  func(f *types.LambdaFunctionData) string { return f.ConsoleURL },
+		},
+		{
+			Name:  "LastModified",
+			Value: func(f *types.LambdaFunctionData) string { return formatTime(f.LastModified) },
+		},
+		{
+			Name:  "FunctionArn",
+			Value: func(f *types.LambdaFunctionData) string { return f.FunctionArn },
+		},
+	}
+}
+
+// GetAuditColumns returns the columns of the environment variable audit. Values of environment variables are never included.
+func GetAuditColumns() []Column {
+	return []Column{
+		{
+			Name:  "Runtime",
+			Value: func(f *types.LambdaFunctionData) string { return string(f.Runtime) },
+		},
+		{
+			Name:  "Region",
+			Value: func(f *types.LambdaFunctionData) string { return f.Region },
+		},
+		{
+			Name:  "FunctionName",
+			Value: func(f *types.LambdaFunctionData) string { return f.FunctionName },
+			Link:  func(f *types.LambdaFunctionData) string { return f.ConsoleURL },
+		},
+		{
+			Name:  "EnvVariables",
+			Value: func(f *types.LambdaFunctionData) string { return strconv.Itoa(f.EnvVariables) },
+			Data:  func(f *types.LambdaFunctionData) any { return f.EnvVariables },
+		},
+		{
+			Name: "Findings",
+			Value: func(f *types.LambdaFunctionData) string {
+				return strings.Join(describeEnvFindings(f.EnvFindings), ", ")
+			},
+			Data: func(f *types.LambdaFunctionData) any { return describeEnvFindings(f.EnvFindings) },
+		},
+		{
+			Name:  "EnvEncryption",
+			Value: formatEnvEncryption,
+		},
+		{
+			Name:  "KMSKeyArn",
+			Value: func(f *types.LambdaFunctionData) string { return f.KMSKeyArn },
+		},
+	}
+}
+
+func describeEnvFindings(findings []types.EnvFinding) []string {
+	descriptions := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		descriptions = append(descriptions, fmt.Sprintf("%s=%s (%s)", finding.Name, maskedValue, finding.Reason))
+	}
+	return descriptions
+}
+
+func formatEnvEncryption(f *types.LambdaFunctionData) string {
+	if f.EnvVariables == 0 {
+		return ""
+	}
+	if f.KMSKeyArn != "" {
+		return "customer managed key"
+	}
+	return "AWS managed key"
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(client.LambdaTimestampLayout)
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
@@ -0,0 +1,35 @@
+package io
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// CSVRenderer renders the result as delimiter-separated values, such as CSV or TSV.
+type CSVRenderer struct {
+	Comma rune
+}
+
+var _ Renderer = (*CSVRenderer)(nil)
+
+func (r *CSVRenderer) Render(result *Result) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	if r.Comma != 0 {
+		w.Comma = r.Comma
+	}
+	var outputData [][]string
+
+	outputData = append(outputData, result.Header())
+	outputData = append(outputData, result.Rows()...)
+
+	if err := w.WriteAll(outputData); err != nil {
+		return nil, err
+	}
+
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
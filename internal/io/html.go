@@ -0,0 +1,175 @@
+package io
+
+import (
+	"bytes"
+	"html/template"
+	"sort"
+	"time"
+)
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>lamver report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.2em; margin-top: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; }
+th { background: #f6f8fa; }
+table.sortable th { cursor: pointer; user-select: none; }
+table.sortable th[data-order="asc"]::after { content: " \25B2"; }
+table.sortable th[data-order="desc"]::after { content: " \25BC"; }
+.summary { display: flex; gap: 2em; flex-wrap: wrap; }
+</style>
+</head>
+<body>
+<h1>lamver report</h1>
+<p>Generated at {{ .GeneratedAt }}. {{ len .Rows }} functions found.</p>
+<h2>Summary</h2>
+<div class="summary">
+{{- range .Summaries }}
+<table>
+<thead><tr><th>{{ .Column }}</th><th>Count</th></tr></thead>
+<tbody>
+{{- range .Counts }}
+<tr><td>{{ .Value }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+</div>
+<h2>Functions</h2>
+<table class="sortable">
+<thead><tr>{{ range .Header }}<th>{{ . }}</th>{{ end }}</tr></thead>
+<tbody>
+{{- range .Rows }}
+<tr>{{ range . }}<td>{{ if .URL }}<a href="{{ .URL }}">{{ .Text }}</a>{{ else }}{{ .Text }}{{ end }}</td>{{ end }}</tr>
+{{- end }}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (th, column) {
+    th.addEventListener("click", function () {
+      var order = th.dataset.order === "asc" ? "desc" : "asc";
+      table.querySelectorAll("th").forEach(function (h) { delete h.dataset.order; });
+      th.dataset.order = order;
+      var tbody = table.tBodies[0];
+      var rows = Array.prototype.slice.call(tbody.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[column].textContent, y = b.cells[column].textContent;
+        var c = x.localeCompare(y, undefined, { numeric: true });
+        return order === "asc" ? c : -c;
+      });
+      rows.forEach(function (row) { tbody.appendChild(row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`
+
+// summaryColumns are the columns counted in the summary section of the HTML report.
+var summaryColumns = []string{"Runtime", "Region"}
+
+type HTMLRenderer struct{}
+
+var _ Renderer = (*HTMLRenderer)(nil)
+
+type htmlCell struct {
+	Text string
+	URL  string
+}
+
+type htmlCount struct {
+	Value string
+	Count int
+}
+
+type htmlSummary struct {
+	Column string
+	Counts []htmlCount
+}
+
+// Render renders the result as a self-contained HTML file with sortable columns and a summary.
+func (r *HTMLRenderer) Render(result *Result) ([]byte, error) {
+	tmpl, err := template.New("report").Parse(htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	header := result.Header()
+	resultRows := result.Rows()
+
+	rows := make([][]htmlCell, 0, len(resultRows))
+	for i, row := range resultRows {
+		cells := make([]htmlCell, 0, len(row))
+		for j, cell := range row {
+			cells = append(cells, htmlCell{
+				Text: cell,
+				URL:  result.Link(i, j),
+			})
+		}
+		rows = append(rows, cells)
+	}
+
+	summaries := []htmlSummary{}
+	for _, column := range summaryColumns {
+		counts, ok := countByColumn(header, resultRows, column)
+		if !ok {
+			continue
+		}
+		summaries = append(summaries, htmlSummary{
+			Column: column,
+			Counts: counts,
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	err = tmpl.Execute(buf, map[string]any{
+		"GeneratedAt": time.Now().UTC().Format(time.RFC3339),
+		"Header":      header,
+		"Rows":        rows,
+		"Summaries":   summaries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func countByColumn(header []string, rows [][]string, column string) ([]htmlCount, bool) {
+	index := -1
+	for i, h := range header {
+		if h == column {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, false
+	}
+
+	countMap := make(map[string]int)
+	for _, row := range rows {
+		countMap[row[index]]++
+	}
+
+	counts := make([]htmlCount, 0, len(countMap))
+	for value, count := range countMap {
+		counts = append(counts, htmlCount{Value: value, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+
+	return counts, true
+}
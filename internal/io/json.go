@@ -0,0 +1,50 @@
+package io
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+type JSONRenderer struct{}
+
+var _ Renderer = (*JSONRenderer)(nil)
+
+// Render renders the result as a JSON array of objects, keeping the order of the columns.
+// Numbers and lists are kept as they are, and empty values are null.
+func (r *JSONRenderer) Render(result *Result) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	header := result.Header()
+	records := result.Records()
+
+	buf.WriteString("[")
+	for i, record := range records {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  {")
+		for j, h := range header {
+			if j > 0 {
+				buf.WriteString(",")
+			}
+			key, err := json.Marshal(h)
+			if err != nil {
+				return nil, err
+			}
+			value, err := json.Marshal(record[j])
+			if err != nil {
+				return nil, err
+			}
+			buf.WriteString("\n    ")
+			buf.Write(key)
+			buf.WriteString(": ")
+			buf.Write(value)
+		}
+		buf.WriteString("\n  }")
+	}
+	if len(records) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,17 @@
+package io
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	fmt.Println()
+	fmt.Println("==========================================")
+	fmt.Println("========== Start Test: io ================")
+	fmt.Println("==========================================")
+	NewLogger(false)
+	goleak.VerifyTestMain(m)
+}
@@ -0,0 +1,46 @@
+package io
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+type MarkdownRenderer struct{}
+
+var _ Renderer = (*MarkdownRenderer)(nil)
+
+// Render renders the result as a GitHub-flavored markdown table.
+func (r *MarkdownRenderer) Render(result *Result) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	header := make([]string, 0, len(result.Columns))
+	separator := make([]string, 0, len(result.Columns))
+	for _, h := range result.Header() {
+		header = append(header, escapeMarkdownCell(h))
+		separator = append(separator, "---")
+	}
+	fmt.Fprintf(buf, "| %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(buf, "| %s |\n", strings.Join(separator, " | "))
+
+	for i, row := range result.Rows() {
+		cells := make([]string, 0, len(row))
+		for j, cell := range row {
+			cell = escapeMarkdownCell(cell)
+			if url := result.Link(i, j); url != "" {
+				cell = fmt.Sprintf("[%s](%s)", cell, url)
+			}
+			cells = append(cells, cell)
+		}
+		fmt.Fprintf(buf, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	return buf.Bytes(), nil
+}
+
+func escapeMarkdownCell(cell string) string {
+	cell = strings.ReplaceAll(cell, "|", "\\|")
+	cell = strings.ReplaceAll(cell, "\r\n", " ")
+	cell = strings.ReplaceAll(cell, "\n", " ")
+	return cell
+}
@@ -0,0 +1,158 @@
+package io
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	fuzzyScoreMatch       = 16
+	fuzzyBonusConsecutive = 8
+	fuzzyBonusBoundary    = 8
+	fuzzyBonusFirst       = 4
+	fuzzyPenaltyGap       = 1
+)
+
+// match is the result of matching a label with the keyword.
+type match struct {
+	// positions are the indexes of the matched runes in the label, to be highlighted.
+	positions []int
+	// score ranks fuzzy matches. It is zero in the other modes.
+	score int
+}
+
+// matchFunc reports whether the label matches the keyword, and how.
+type matchFunc func(label string) (match, bool)
+
+func newMatchFunc(mode FilterMode, keyword string) matchFunc {
+	switch mode {
+	case FilterFuzzy:
+		keywordRunes := []rune(strings.ToLower(keyword))
+		return func(label string) (match, bool) {
+			return matchFuzzy([]rune(strings.ToLower(label)), keywordRunes)
+		}
+	case FilterRegex:
+		re, err := regexp.Compile("(?i)" + keyword)
+		if err != nil {
+			// an invalid regular expression matches nothing
+			return func(string) (match, bool) { return match{}, false }
+		}
+		return func(label string) (match, bool) {
+			return matchRegex(label, re)
+		}
+	default:
+		keywordRunes := []rune(strings.ToLower(keyword))
+		return func(label string) (match, bool) {
+			return matchSubstring([]rune(strings.ToLower(label)), keywordRunes)
+		}
+	}
+}
+
+func matchSubstring(label []rune, keyword []rune) (match, bool) {
+	for start := 0; start+len(keyword) <= len(label); start++ {
+		if string(label[start:start+len(keyword)]) == string(keyword) {
+			return match{positions: sequence(start, start+len(keyword))}, true
+		}
+	}
+	return match{}, false
+}
+
+func matchRegex(label string, re *regexp.Regexp) (match, bool) {
+	loc := re.FindStringIndex(label)
+	if loc == nil {
+		return match{}, false
+	}
+	start := utf8.RuneCountInString(label[:loc[0]])
+	end := start + utf8.RuneCountInString(label[loc[0]:loc[1]])
+	return match{positions: sequence(start, end)}, true
+}
+
+// matchFuzzy matches the label containing the runes of the keyword in order, such as "python3.9" for "py39".
+// Each occurrence of the first rune is tried as the start, and the best scored match is returned.
+// Consecutive runes and runes at the start of a word score higher, and gaps between them score lower.
+func matchFuzzy(label []rune, keyword []rune) (match, bool) {
+	if len(keyword) == 0 {
+		return match{}, true
+	}
+
+	best := match{}
+	found := false
+	for start := range label {
+		if label[start] != keyword[0] {
+			continue
+		}
+		positions, ok := matchFuzzyFrom(label, keyword, start)
+		if !ok {
+			// later starts cannot match either
+			break
+		}
+		score := scoreFuzzy(label, positions)
+		if !found || score > best.score {
+			best = match{positions: positions, score: score}
+			found = true
+		}
+	}
+	return best, found
+}
+
+func matchFuzzyFrom(label []rune, keyword []rune, start int) ([]int, bool) {
+	positions := make([]int, 0, len(keyword))
+	next := 0
+	for i := start; i < len(label) && next < len(keyword); i++ {
+		if label[i] == keyword[next] {
+			positions = append(positions, i)
+			next++
+		}
+	}
+	return positions, next == len(keyword)
+}
+
+func scoreFuzzy(label []rune, positions []int) int {
+	score := 0
+	for n, position := range positions {
+		score += fuzzyScoreMatch
+		if isWordBoundary(label, position) {
+			score += fuzzyBonusBoundary
+		}
+		if n == 0 {
+			if position == 0 {
+				score += fuzzyBonusFirst
+			}
+			continue
+		}
+		if gap := position - positions[n-1] - 1; gap == 0 {
+			score += fuzzyBonusConsecutive
+		} else {
+			score -= gap * fuzzyPenaltyGap
+		}
+	}
+	return score
+}
+
+// isWordBoundary reports whether the rune at the position starts a word, such as "3" in "python3.9".
+func isWordBoundary(label []rune, position int) bool {
+	if position == 0 {
+		return true
+	}
+	prev, current := label[position-1], label[position]
+	switch {
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev):
+		return true
+	case unicode.IsLetter(prev) && unicode.IsDigit(current):
+		return true
+	case unicode.IsDigit(prev) && unicode.IsLetter(current):
+		return true
+	default:
+		return false
+	}
+}
+
+func sequence(start int, end int) []int {
+	s := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		s = append(s, i)
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package io
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewMatchFunc(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          FilterMode
+		keyword       string
+		label         string
+		want          bool
+		wantPositions []int
+	}{
+		{
+			name:          "substring match",
+			mode:          FilterSubstring,
+			keyword:       "JS18",
+			label:         "nodejs18.x",
+			want:          true,
+			wantPositions: []int{4, 5, 6, 7},
+		},
+		{
+			name:    "substring no match for non-contiguous characters",
+			mode:    FilterSubstring,
+			keyword: "py39",
+			label:   "python3.9",
+			want:    false,
+		},
+		{
+			name:          "fuzzy match for non-contiguous characters",
+			mode:          FilterFuzzy,
+			keyword:       "py39",
+			label:         "python3.9",
+			want:          true,
+			wantPositions: []int{0, 1, 6, 8},
+		},
+		{
+			name:          "fuzzy match prefers consecutive characters",
+			mode:          FilterFuzzy,
+			keyword:       "al2",
+			label:         "java8.al2",
+			want:          true,
+			wantPositions: []int{6, 7, 8},
+		},
+		{
+			name:    "fuzzy no match for characters out of order",
+			mode:    FilterFuzzy,
+			keyword: "93",
+			label:   "python3.9",
+			want:    false,
+		},
+		{
+			name:          "regex match",
+			mode:          FilterRegex,
+			keyword:       `3\.1\d`,
+			label:         "python3.12",
+			want:          true,
+			wantPositions: []int{6, 7, 8, 9},
+		},
+		{
+			name:    "invalid regex matches nothing",
+			mode:    FilterRegex,
+			keyword: "python[",
+			label:   "python[",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newMatchFunc(tt.mode, tt.keyword)(tt.label)
+			if ok != tt.want {
+				t.Errorf("match = %v, want %v", ok, tt.want)
+				return
+			}
+			if ok && !reflect.DeepEqual(got.positions, tt.wantPositions) {
+				t.Errorf("positions = %v, want %v", got.positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestMatchFuzzy_Score(t *testing.T) {
+	keyword := []rune("p3")
+	python, _ := matchFuzzy([]rune("python3.9"), keyword)
+	provided, _ := matchFuzzy([]rune("provided.al2023"), keyword)
+
+	// "3" starts the version in python3.9, and is far from "p" in provided.al2023
+	if python.score <= provided.score {
+		t.Errorf("score of python3.9 = %d, want more than provided.al2023 = %d", python.score, provided.score)
+	}
+}
@@ -1,92 +1,93 @@
 package io
 
 import (
-	"encoding/csv"
 	"fmt"
 	"os"
-	"strings"
 
-	"github.com/olekukonko/tablewriter"
-	"github.com/olekukonko/tablewriter/tw"
+	"github.com/go-to-k/lamver/internal/types"
+
+	"github.com/mattn/go-isatty"
 )
 
-func OutputResult(functionHeader []string, functionData [][]string, csvOutputFilePath string) error {
-	if csvOutputFilePath != "" {
-		if err := outputAsCSV(functionHeader, functionData, csvOutputFilePath); err != nil {
-			return err
-		}
-	} else {
-		if err := outputAsTable(functionHeader, functionData); err != nil {
-			return err
-		}
+// StdoutFilePath is the output file path that means stdout.
+const StdoutFilePath = "-"
+
+// OutputResult renders the functions in the format and writes it to outputFilePath.
+// If outputFilePath is "-", the result is written to stdout. If it is empty,
+// the table is written to stderr and the other formats to stdout.
+func OutputResult(columns []Column, functions []types.LambdaFunctionData, format string, outputFilePath string) error {
+	result := &Result{
+		Columns:   columns,
+		Functions: functions,
+	}
+	if err := output(result, format, outputFilePath); err != nil {
+		return err
 	}
 
-	Logger.Info().Msgf("%d counts hit! ", len(functionData))
+	Logger.Info().Msgf("%d counts hit! ", len(functions))
 
 	return nil
 }
 
-func outputAsTable(header []string, data [][]string) error {
-	tableString := &strings.Builder{}
-	table := tablewriter.NewTable(tableString,
-		tablewriter.WithRendition(
-			tw.Rendition{
-				Symbols: tw.NewSymbols(tw.StyleASCII),
-				Borders: tw.Border{
-					Top:    tw.On,
-					Bottom: tw.On,
-					Left:   tw.On,
-					Right:  tw.On,
-				},
-				Settings: tw.Settings{
-					Separators: tw.Separators{
-						BetweenRows: tw.On,
-					},
-					Lines: tw.Lines{
-						ShowHeaderLine: tw.On,
-					},
-				},
-			},
-		),
-	)
-
-	table.Header(header)
-	if err := table.Bulk(data); err != nil {
-		return err
+// OutputCells renders the cells under the header in the format and writes it to outputFilePath,
+// in the same way as OutputResult. It is for reports other than functions, such as summaries.
+func OutputCells(header []string, cells [][]any, format string, outputFilePath string) error {
+	columns := make([]Column, 0, len(header))
+	for _, name := range header {
+		columns = append(columns, Column{Name: name})
 	}
-	if err := table.Render(); err != nil {
+	return output(&Result{Columns: columns, Cells: cells}, format, outputFilePath)
+}
+
+func output(result *Result, format string, outputFilePath string) error {
+	renderer, err := GetRenderer(format)
+	if err != nil {
 		return err
 	}
 
-	stringAsTableFormat := tableString.String()
+	var dest *os.File
+	switch {
+	case outputFilePath == "" && format == FormatTable:
+		dest = os.Stderr
+	case outputFilePath == "" || outputFilePath == StdoutFilePath:
+		dest = os.Stdout
+	}
+	result.Hyperlinks = dest != nil && supportsHyperlink(dest)
 
-	fmt.Fprintf(os.Stderr, "%s", stringAsTableFormat)
+	out, err := renderer.Render(result)
+	if err != nil {
+		return err
+	}
 
+	if dest != nil {
+		fmt.Fprintf(dest, "%s", out)
+		return nil
+	}
+	if err := writeFile(outputFilePath, out); err != nil {
+		return err
+	}
+	Logger.Info().Msg("Finished writing output!")
 	return nil
 }
 
-func outputAsCSV(header []string, data [][]string, csvOutputFilePath string) error {
-	file, err := os.Create(csvOutputFilePath)
+// writeFile creates the file with the default permissions, as other users may read the report.
+func writeFile(path string, data []byte) error {
+	file, err := os.Create(path)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	w := csv.NewWriter(file)
-	var outputData [][]string
-
-	outputData = append(outputData, header)
-	outputData = append(outputData, data...)
-
-	if err := w.WriteAll(outputData); err != nil {
-		return err
-	}
-
-	if err := w.Error(); err != nil {
+	if _, err := file.Write(data); err != nil {
 		return err
 	}
+	return file.Close()
+}
 
-	Logger.Info().Msg("Finished writing output!")
+func supportsHyperlink(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) && os.Getenv("TERM") != "dumb"
+}
 
-	return nil
+func toHyperlink(text string, url string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
 }
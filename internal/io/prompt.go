@@ -0,0 +1,144 @@
+package io
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+)
+
+// Prompter asks the user. It is replaced by a scripted one in tests.
+type Prompter interface {
+	// Checkboxes lets the user select options once. Labels are shown instead of the options if not empty,
+	// and the options in selected are checked at the start. It returns false if the user cancels.
+	Checkboxes(headers []string, opts []string, labels []string, selected []string) ([]string, bool, error)
+	// Input asks a line of text, filled with the value at the start. It returns false if the user cancels.
+	Input(label string, value string) (string, bool, error)
+	// Confirm asks yes or no. It returns false as ok if the user cancels, to abort the prompts.
+	Confirm(label string) (answer bool, ok bool, err error)
+}
+
+// TUIPrompter asks the user in the terminal. The prompts are written to stderr not to mix with the results.
+type TUIPrompter struct {
+	// History is the text entered by Input, the oldest first. It can be set to recall the inputs of previous runs.
+	History []string
+}
+
+var _ Prompter = (*TUIPrompter)(nil)
+
+func NewTUIPrompter() *TUIPrompter {
+	return &TUIPrompter{History: []string{}}
+}
+
+func (p *TUIPrompter) Checkboxes(headers []string, opts []string, labels []string, selected []string) ([]string, bool, error) {
+	ui := NewUIWithLabels(opts, labels, headers)
+	ui.Preselect(selected)
+	if err := runProgram(ui); err != nil {
+		return nil, false, err
+	}
+	if ui.IsCanceled {
+		return []string{}, false, nil
+	}
+
+	checkboxes := []string{}
+	for c := range ui.Choices {
+		if _, ok := ui.Selected[c]; ok {
+			checkboxes = append(checkboxes, ui.Choices[c])
+		}
+	}
+	return checkboxes, true, nil
+}
+
+func (p *TUIPrompter) Input(label string, value string) (string, bool, error) {
+	input := NewTextInput(label, value, p.History)
+	if err := runProgram(input); err != nil {
+		return "", false, err
+	}
+	if input.IsCanceled {
+		return "", false, nil
+	}
+
+	// the same input in a row is kept once, as the history is remembered across runs
+	s := strings.TrimSpace(input.Value)
+	if s != "" && (len(p.History) == 0 || p.History[len(p.History)-1] != s) {
+		p.History = append(p.History, s)
+	}
+	return s, true, nil
+}
+
+func (p *TUIPrompter) Confirm(label string) (bool, bool, error) {
+	yesNo := NewYesNo(label)
+	if err := runProgram(yesNo); err != nil {
+		return false, false, err
+	}
+	if yesNo.IsCanceled {
+		return false, false, nil
+	}
+	return yesNo.Answer, true, nil
+}
+
+func runProgram(model tea.Model) error {
+	_, err := tea.NewProgram(model, tea.WithOutput(os.Stderr)).Run()
+	return err
+}
+
+// AssumeYes returns the prompter answering yes to all confirmations without asking, for --yes.
+func AssumeYes(p Prompter) Prompter {
+	return &assumeYesPrompter{Prompter: p}
+}
+
+type assumeYesPrompter struct {
+	Prompter
+}
+
+func (p *assumeYesPrompter) Confirm(label string) (bool, bool, error) {
+	Logger.Info().Msgf("%s Yes", label)
+	return true, true, nil
+}
+
+// GetCheckboxes lets the user select options, and confirms them. If nothing is selected or the user cancels,
+// it asks whether to finish, and returns false as continuation if so. Canceling a confirmation also finishes.
+func GetCheckboxes(p Prompter, headers []string, opts []string, labels []string, selected []string) ([]string, bool, error) {
+	for {
+		checkboxes, ok, err := p.Checkboxes(headers, opts, labels, selected)
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch {
+		case !ok:
+			Logger.Warn().Msg("Canceled!")
+		case len(checkboxes) == 0:
+			Logger.Warn().Msg("Not selected!")
+		}
+		if len(checkboxes) == 0 || !ok {
+			finish, ok, err := p.Confirm("Do you want to finish?")
+			if err != nil {
+				return nil, false, err
+			}
+			if finish || !ok {
+				Logger.Info().Msg("Finished...")
+				return checkboxes, false, nil
+			}
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, " %s\n", color.CyanString(strings.Join(checkboxes, ", ")))
+
+		confirmed, ok, err := p.Confirm("OK?")
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			Logger.Info().Msg("Finished...")
+			return checkboxes, false, nil
+		}
+		if confirmed {
+			return checkboxes, true, nil
+		}
+		// the selection is kept when asked again
+		selected = checkboxes
+	}
+}
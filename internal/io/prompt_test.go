@@ -0,0 +1,228 @@
+package io
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTextInput(t *testing.T) {
+	input := NewTextInput("Keyword: ", "goto", []string{"first", "second"})
+
+	input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-app")})
+	input.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if input.Value != "goto-ap" {
+		t.Errorf("Value = %q, want goto-ap", input.Value)
+	}
+
+	// up and down go through the history, and back to the value being edited
+	input.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if input.Value != "second" {
+		t.Errorf("Value = %q, want second", input.Value)
+	}
+	input.Update(tea.KeyMsg{Type: tea.KeyUp})
+	input.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if input.Value != "first" {
+		t.Errorf("Value = %q, want first", input.Value)
+	}
+	input.Update(tea.KeyMsg{Type: tea.KeyDown})
+	input.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if input.Value != "goto-ap" {
+		t.Errorf("Value = %q, want goto-ap", input.Value)
+	}
+
+	input.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("my func\n"), Paste: true})
+	if input.Value != "my func" {
+		t.Errorf("Value = %q, want my func", input.Value)
+	}
+
+	if _, cmd := input.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || !input.IsEntered {
+		t.Errorf("IsEntered = %v, want entered and quit", input.IsEntered)
+	}
+}
+
+func TestTextInput_Cancel(t *testing.T) {
+	input := NewTextInput("Keyword: ", "", nil)
+
+	input.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if input.Value != "" {
+		t.Errorf("Value = %q, want empty without history", input.Value)
+	}
+	if _, cmd := input.Update(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd == nil || !input.IsCanceled {
+		t.Errorf("IsCanceled = %v, want canceled and quit", input.IsCanceled)
+	}
+}
+
+func TestYesNo(t *testing.T) {
+	tests := []struct {
+		name         string
+		keys         []tea.KeyMsg
+		wantAnswer   bool
+		wantAnswered bool
+	}{
+		{
+			name:         "y answers yes",
+			keys:         []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("y")}},
+			wantAnswer:   true,
+			wantAnswered: true,
+		},
+		{
+			name:         "N answers no",
+			keys:         []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("N")}},
+			wantAnswer:   false,
+			wantAnswered: true,
+		},
+		{
+			name:         "enter answers yes",
+			keys:         []tea.KeyMsg{{Type: tea.KeyEnter}},
+			wantAnswer:   true,
+			wantAnswered: true,
+		},
+		{
+			name:         "other keys are ignored",
+			keys:         []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("x")}, {Type: tea.KeyRunes, Runes: []rune("n")}},
+			wantAnswer:   false,
+			wantAnswered: true,
+		},
+		{
+			name:         "ctrl+c cancels as no",
+			keys:         []tea.KeyMsg{{Type: tea.KeyCtrlC}},
+			wantAnswer:   false,
+			wantAnswered: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yesNo := NewYesNo("OK?")
+			for _, key := range tt.keys {
+				yesNo.Update(key)
+			}
+			if yesNo.Answer != tt.wantAnswer || yesNo.IsAnswered != tt.wantAnswered {
+				t.Errorf("Answer = %v, IsAnswered = %v, want %v and %v", yesNo.Answer, yesNo.IsAnswered, tt.wantAnswer, tt.wantAnswered)
+			}
+		})
+	}
+}
+
+// fakePrompter returns the checkboxes and the confirmations in order.
+type fakePrompter struct {
+	checkboxes [][]string
+	// confirms are "y", "n" or "cancel"
+	confirms      []string
+	gotSelected   [][]string
+	confirmLabels []string
+}
+
+func (p *fakePrompter) Checkboxes(headers []string, opts []string, labels []string, selected []string) ([]string, bool, error) {
+	p.gotSelected = append(p.gotSelected, selected)
+	checkboxes := p.checkboxes[0]
+	p.checkboxes = p.checkboxes[1:]
+	// nil means canceled
+	return checkboxes, checkboxes != nil, nil
+}
+
+func (p *fakePrompter) Input(label string, value string) (string, bool, error) {
+	return value, true, nil
+}
+
+func (p *fakePrompter) Confirm(label string) (bool, bool, error) {
+	p.confirmLabels = append(p.confirmLabels, label)
+	confirm := p.confirms[0]
+	p.confirms = p.confirms[1:]
+	return confirm == "y", confirm != "cancel", nil
+}
+
+func TestGetCheckboxes(t *testing.T) {
+	opts := []string{"ap-northeast-1", "us-east-1"}
+
+	tests := []struct {
+		name              string
+		prompter          *fakePrompter
+		assumeYes         bool
+		want              []string
+		wantContinuation  bool
+		wantConfirmLabels []string
+		wantSelected      [][]string
+	}{
+		{
+			name:              "confirmed",
+			prompter:          &fakePrompter{checkboxes: [][]string{{"us-east-1"}}, confirms: []string{"y"}},
+			want:              []string{"us-east-1"},
+			wantContinuation:  true,
+			wantConfirmLabels: []string{"OK?"},
+			wantSelected:      [][]string{{"ap-northeast-1"}},
+		},
+		{
+			name:              "selected again with the last selection if not confirmed",
+			prompter:          &fakePrompter{checkboxes: [][]string{{"us-east-1"}, opts}, confirms: []string{"n", "y"}},
+			want:              opts,
+			wantContinuation:  true,
+			wantConfirmLabels: []string{"OK?", "OK?"},
+			wantSelected:      [][]string{{"ap-northeast-1"}, {"us-east-1"}},
+		},
+		{
+			name:              "finished if canceled",
+			prompter:          &fakePrompter{checkboxes: [][]string{nil}, confirms: []string{"y"}},
+			want:              []string(nil),
+			wantContinuation:  false,
+			wantConfirmLabels: []string{"Do you want to finish?"},
+			wantSelected:      [][]string{{"ap-northeast-1"}},
+		},
+		{
+			name:              "finished if the finish confirmation is canceled",
+			prompter:          &fakePrompter{checkboxes: [][]string{nil}, confirms: []string{"cancel"}},
+			want:              []string(nil),
+			wantContinuation:  false,
+			wantConfirmLabels: []string{"Do you want to finish?"},
+			wantSelected:      [][]string{{"ap-northeast-1"}},
+		},
+		{
+			name:              "finished if the selection confirmation is canceled",
+			prompter:          &fakePrompter{checkboxes: [][]string{{"us-east-1"}}, confirms: []string{"cancel"}},
+			want:              []string{"us-east-1"},
+			wantContinuation:  false,
+			wantConfirmLabels: []string{"OK?"},
+			wantSelected:      [][]string{{"ap-northeast-1"}},
+		},
+		{
+			name:              "selected again if nothing is selected and not finished",
+			prompter:          &fakePrompter{checkboxes: [][]string{{}, {"us-east-1"}}, confirms: []string{"n", "y"}},
+			want:              []string{"us-east-1"},
+			wantContinuation:  true,
+			wantConfirmLabels: []string{"Do you want to finish?", "OK?"},
+			wantSelected:      [][]string{{"ap-northeast-1"}, {"ap-northeast-1"}},
+		},
+		{
+			name:              "confirmations are skipped by AssumeYes",
+			prompter:          &fakePrompter{checkboxes: [][]string{{"us-east-1"}}},
+			assumeYes:         true,
+			want:              []string{"us-east-1"},
+			wantContinuation:  true,
+			wantConfirmLabels: []string(nil),
+			wantSelected:      [][]string{{"ap-northeast-1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Prompter = tt.prompter
+			if tt.assumeYes {
+				p = AssumeYes(p)
+			}
+			got, continuation, err := GetCheckboxes(p, []string{"Select regions."}, opts, nil, []string{"ap-northeast-1"})
+			if err != nil {
+				t.Fatalf("GetCheckboxes() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || continuation != tt.wantContinuation {
+				t.Errorf("GetCheckboxes() = %v, %v, want %v, %v", got, continuation, tt.want, tt.wantContinuation)
+			}
+			if !reflect.DeepEqual(tt.prompter.confirmLabels, tt.wantConfirmLabels) {
+				t.Errorf("confirmations = %v, want %v", tt.prompter.confirmLabels, tt.wantConfirmLabels)
+			}
+			if !reflect.DeepEqual(tt.prompter.gotSelected, tt.wantSelected) {
+				t.Errorf("preselected = %v, want %v", tt.prompter.gotSelected, tt.wantSelected)
+			}
+		})
+	}
+}
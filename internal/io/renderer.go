@@ -0,0 +1,166 @@
+package io
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-to-k/lamver/internal/types"
+)
+
+const (
+	FormatTable    = "table"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Result is the model that every Renderer renders from.
+type Result struct {
+	Columns   []Column
+	Functions []types.LambdaFunctionData
+	// Cells are rendered instead of the functions if not nil, for reports other than functions such as summaries.
+	// The columns then give only the names, and have no links. A cell is a string, a number, a list of strings or nil.
+	Cells [][]any
+	// Hyperlinks enables OSC 8 hyperlinks for renderers writing to a terminal.
+	Hyperlinks bool
+}
+
+func (r *Result) Header() []string {
+	header := make([]string, 0, len(r.Columns))
+	for _, c := range r.Columns {
+		header = append(header, c.Name)
+	}
+	return header
+}
+
+// Rows returns the cells as text, for the formats of text such as table, markdown and HTML.
+func (r *Result) Rows() [][]string {
+	if r.Cells != nil {
+		rows := make([][]string, 0, len(r.Cells))
+		for _, cells := range r.Cells {
+			row := make([]string, 0, len(cells))
+			for _, cell := range cells {
+				row = append(row, formatCell(cell))
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	}
+	rows := make([][]string, 0, len(r.Functions))
+	for i := range r.Functions {
+		row := make([]string, 0, len(r.Columns))
+		for _, c := range r.Columns {
+			row = append(row, c.Value(&r.Functions[i]))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Records returns the cells as typed values, for the structured formats such as JSON and YAML.
+// Numbers are kept as numbers, lists as lists, and empty values are nil.
+func (r *Result) Records() [][]any {
+	if r.Cells != nil {
+		records := make([][]any, 0, len(r.Cells))
+		for _, cells := range r.Cells {
+			record := make([]any, 0, len(cells))
+			for _, cell := range cells {
+				if cell == "" {
+					cell = nil
+				}
+				record = append(record, cell)
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+	records := make([][]any, 0, len(r.Functions))
+	for i := range r.Functions {
+		record := make([]any, 0, len(r.Columns))
+		for _, c := range r.Columns {
+			record = append(record, c.data(&r.Functions[i]))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func formatCell(cell any) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Link returns the URL that the cell links to, or an empty string.
+func (r *Result) Link(row int, column int) string {
+	c := r.Columns[column]
+	if r.Cells != nil || c.Link == nil || c.Value(&r.Functions[row]) == "" {
+		return ""
+	}
+	return c.Link(&r.Functions[row])
+}
+
+// Renderer renders a Result into a specific output format.
+type Renderer interface {
+	Render(result *Result) ([]byte, error)
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = map[string]Renderer{
+		FormatTable:    &TableRenderer{},
+		FormatCSV:      &CSVRenderer{Comma: ','},
+		FormatTSV:      &CSVRenderer{Comma: '\t'},
+		FormatJSON:     &JSONRenderer{},
+		FormatYAML:     &YAMLRenderer{},
+		FormatMarkdown: &MarkdownRenderer{},
+		FormatHTML:     &HTMLRenderer{},
+	}
+)
+
+// RegisterRenderer registers a renderer for the format, replacing any renderer already registered for it.
+func RegisterRenderer(format string, renderer Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	renderers[format] = renderer
+}
+
+func GetFormats() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+func ValidateFormat(format string) error {
+	_, err := GetRenderer(format)
+	return err
+}
+
+func GetRenderer(format string) (Renderer, error) {
+	renderersMu.RLock()
+	renderer, ok := renderers[format]
+	renderersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("invalid format: %s, must be one of %s", format, strings.Join(GetFormats(), ", "))
+	}
+	return renderer, nil
+}
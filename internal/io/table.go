@@ -0,0 +1,58 @@
+package io
+
+import (
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+type TableRenderer struct{}
+
+var _ Renderer = (*TableRenderer)(nil)
+
+func (r *TableRenderer) Render(result *Result) ([]byte, error) {
+	tableString := &strings.Builder{}
+	table := tablewriter.NewTable(tableString,
+		tablewriter.WithRendition(
+			tw.Rendition{
+				Symbols: tw.NewSymbols(tw.StyleASCII),
+				Borders: tw.Border{
+					Top:    tw.On,
+					Bottom: tw.On,
+					Left:   tw.On,
+					Right:  tw.On,
+				},
+				Settings: tw.Settings{
+					Separators: tw.Separators{
+						BetweenRows: tw.On,
+					},
+					Lines: tw.Lines{
+						ShowHeaderLine: tw.On,
+					},
+				},
+			},
+		),
+	)
+
+	rows := result.Rows()
+	if result.Hyperlinks {
+		for i, row := range rows {
+			for j := range row {
+				if url := result.Link(i, j); url != "" {
+					row[j] = toHyperlink(row[j], url)
+				}
+			}
+		}
+	}
+
+	table.Header(result.Header())
+	if err := table.Bulk(rows); err != nil {
+		return nil, err
+	}
+	if err := table.Render(); err != nil {
+		return nil, err
+	}
+
+	return []byte(tableString.String()), nil
+}
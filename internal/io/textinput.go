@@ -0,0 +1,106 @@
+package io
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+)
+
+// TextInput is the model to input a line of text. Up and down keys go through the history.
+type TextInput struct {
+	Label string
+	Value string
+	// History is the previous inputs, the oldest first.
+	History    []string
+	IsEntered  bool
+	IsCanceled bool
+
+	// historyIndex is the position in History shown as the value. len(History) means the value being edited.
+	historyIndex int
+	// editing is the value being edited, kept while going through the history.
+	editing string
+}
+
+var _ tea.Model = (*TextInput)(nil)
+
+// NewTextInput returns the model with the value filled at the start.
+func NewTextInput(label string, value string, history []string) *TextInput {
+	return &TextInput{
+		Label:        label,
+		Value:        value,
+		History:      history,
+		historyIndex: len(history),
+	}
+}
+
+func (t *TextInput) Init() tea.Cmd {
+	return nil
+}
+
+func (t *TextInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return t, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEnter:
+		t.IsEntered = true
+		return t, tea.Quit
+
+	case tea.KeyCtrlC, tea.KeyEsc:
+		t.IsCanceled = true
+		return t, tea.Quit
+
+	case tea.KeyUp:
+		if t.historyIndex == 0 {
+			return t, nil
+		}
+		if t.historyIndex == len(t.History) {
+			t.editing = t.Value
+		}
+		t.historyIndex--
+		t.Value = t.History[t.historyIndex]
+
+	case tea.KeyDown:
+		if t.historyIndex == len(t.History) {
+			return t, nil
+		}
+		t.historyIndex++
+		if t.historyIndex == len(t.History) {
+			t.Value = t.editing
+		} else {
+			t.Value = t.History[t.historyIndex]
+		}
+
+	case tea.KeyBackspace:
+		runes := []rune(t.Value)
+		if len(runes) > 0 {
+			t.Value = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeyCtrlW, tea.KeyCtrlU:
+		t.Value = ""
+
+	case tea.KeySpace:
+		t.Value += " "
+
+	case tea.KeyRunes:
+		for _, r := range keyMsg.Runes {
+			// a pasted line break is not a part of the value
+			if r == '\n' || r == '\r' {
+				continue
+			}
+			t.Value += string(r)
+		}
+	}
+
+	return t, nil
+}
+
+func (t *TextInput) View() string {
+	s := color.CyanString("? ") + color.New(color.Bold).Sprint(t.Label) + t.Value
+	if t.IsEntered || t.IsCanceled {
+		return s + "\n"
+	}
+	return s + color.CyanString("█") + "\n"
+}
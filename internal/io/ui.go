@@ -2,6 +2,8 @@ package io
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -10,21 +12,68 @@ import (
 
 const SelectionPageSize = 20
 
+// FilterMode is how the keyword filters the choices.
+type FilterMode int
+
+const (
+	// FilterSubstring matches choices containing the keyword.
+	FilterSubstring FilterMode = iota
+	// FilterFuzzy matches choices containing the characters of the keyword in order, e.g. "py312" for "python3.12".
+	FilterFuzzy
+	// FilterRegex matches choices by the keyword as a regular expression.
+	FilterRegex
+)
+
+func (m FilterMode) String() string {
+	switch m {
+	case FilterFuzzy:
+		return "fuzzy"
+	case FilterRegex:
+		return "regex"
+	default:
+		return "substring"
+	}
+}
+
+const helpText = `  up/down, tab/shift+tab  move the cursor
+  pgup/pgdown             move the cursor by a page
+  home/end                move the cursor to the first/last
+  space                   select or deselect
+  right/left              select/deselect all shown
+  ctrl+r                  invert the selection of all shown
+  ctrl+f                  switch the filter mode (substring, fuzzy, regex)
+  backspace/ctrl+w        delete a character/the keyword
+  enter                   finish the selection
+  ctrl+c                  cancel
+  ?                       show/hide this help (when the keyword is empty)
+`
+
 type UI struct {
-	Choices    []string
+	Choices []string
+	// Labels are shown and filtered instead of the choices if not empty. They are in the same order as the choices.
+	Labels     []string
 	Headers    []string
 	Cursor     int
 	Selected   map[int]struct{}
 	Filtered   *Filtered
 	Keyword    string
+	FilterMode FilterMode
+	ShowsHelp  bool
 	IsEntered  bool
 	IsCanceled bool
 }
 
+// Filtered is the choices matching the keyword. Prev is the one for the keyword without the last character,
+// restored by backspace.
 type Filtered struct {
 	Choices map[int]struct{}
-	Prev    *Filtered
-	Cursor  int
+	// Order is the indexes of the matched choices in the order shown. Fuzzy matches are ranked by the score.
+	Order []int
+	// Positions are the indexes of the matched characters in each label, highlighted in the view.
+	Positions map[int][]int
+	Prev      *Filtered
+	// Cursor is the position of the cursor in Order.
+	Cursor int
 }
 
 var _ tea.Model = (*UI)(nil)
@@ -37,12 +86,42 @@ func NewUI(choices []string, headers []string) *UI {
 	}
 }
 
+// NewUIWithLabels returns the UI showing the labels instead of the choices.
+func NewUIWithLabels(choices []string, labels []string, headers []string) *UI {
+	ui := NewUI(choices, headers)
+	ui.Labels = labels
+	return ui
+}
+
+// Preselect checks the choices in the values. Values not in the choices are ignored.
+func (u *UI) Preselect(values []string) {
+	for _, value := range values {
+		for i, choice := range u.Choices {
+			if choice == value {
+				u.Selected[i] = struct{}{}
+			}
+		}
+	}
+}
+
+func (u *UI) label(i int) string {
+	if len(u.Labels) == 0 {
+		return u.Choices[i]
+	}
+	return u.Labels[i]
+}
+
 func (u *UI) Init() tea.Cmd {
-	filtered := make(map[int]struct{})
+	filtered := &Filtered{
+		Choices:   make(map[int]struct{}, len(u.Choices)),
+		Order:     make([]int, 0, len(u.Choices)),
+		Positions: make(map[int][]int),
+	}
 	for i := range u.Choices {
-		filtered[i] = struct{}{}
+		filtered.Choices[i] = struct{}{}
+		filtered.Order = append(filtered.Order, i)
 	}
-	u.Filtered = &Filtered{Choices: filtered}
+	u.Filtered = filtered
 
 	return nil
 }
@@ -53,6 +132,12 @@ func (u *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 
+		// any key closes the help
+		if u.ShowsHelp && msg.Type != tea.KeyCtrlC {
+			u.ShowsHelp = false
+			return u, nil
+		}
+
 		switch msg.Type {
 
 		// Quit the selection
@@ -66,60 +151,28 @@ func (u *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return u, tea.Quit
 
 		case tea.KeyUp, tea.KeyShiftTab:
-			if len(u.Filtered.Choices) < 2 {
+			if len(u.Filtered.Order) < 2 {
 				return u, nil
 			}
-			for range u.Choices {
-				if u.Cursor == 0 {
-					u.Cursor = len(u.Choices) - 1
-				} else if u.Cursor > 0 {
-					u.Cursor--
-				}
-
-				if _, ok := u.Filtered.Choices[u.Cursor]; !ok {
-					continue
-				}
-				if u.Filtered.Cursor == 0 {
-					u.Filtered.Cursor = len(u.Filtered.Choices) - 1
-				} else if u.Filtered.Cursor > 0 {
-					u.Filtered.Cursor--
-				}
-
-				f := u.Filtered
-				for f.Prev != nil {
-					f.Prev.Cursor = u.Filtered.Cursor
-					f = f.Prev
-				}
-				break
-			}
+			u.moveTo((u.Filtered.Cursor - 1 + len(u.Filtered.Order)) % len(u.Filtered.Order))
 
 		case tea.KeyDown, tea.KeyTab:
-			if len(u.Filtered.Choices) < 2 {
+			if len(u.Filtered.Order) < 2 {
 				return u, nil
 			}
-			for range u.Choices {
-				if u.Cursor < len(u.Choices)-1 {
-					u.Cursor++
-				} else if u.Cursor == len(u.Choices)-1 {
-					u.Cursor = 0
-				}
+			u.moveTo((u.Filtered.Cursor + 1) % len(u.Filtered.Order))
 
-				if _, ok := u.Filtered.Choices[u.Cursor]; !ok {
-					continue
-				}
-				if u.Filtered.Cursor < len(u.Filtered.Choices)-1 {
-					u.Filtered.Cursor++
-				} else if u.Filtered.Cursor == len(u.Filtered.Choices)-1 {
-					u.Filtered.Cursor = 0
-				}
+		case tea.KeyPgUp:
+			u.moveTo(u.Filtered.Cursor - SelectionPageSize)
 
-				f := u.Filtered
-				for f.Prev != nil {
-					f.Prev.Cursor = u.Filtered.Cursor
-					f = f.Prev
-				}
-				break
-			}
+		case tea.KeyPgDown:
+			u.moveTo(u.Filtered.Cursor + SelectionPageSize)
+
+		case tea.KeyHome:
+			u.moveTo(0)
+
+		case tea.KeyEnd:
+			u.moveTo(len(u.Filtered.Order) - 1)
 
 		// select or deselect an item
 		case tea.KeySpace:
@@ -157,6 +210,21 @@ func (u *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		// invert the selection in filtered list
+		case tea.KeyCtrlR:
+			for i := range u.Filtered.Choices {
+				if _, ok := u.Selected[i]; ok {
+					delete(u.Selected, i)
+				} else {
+					u.Selected[i] = struct{}{}
+				}
+			}
+
+		// switch the filter mode, and filter again by the keyword
+		case tea.KeyCtrlF:
+			u.FilterMode = (u.FilterMode + 1) % 3
+			u.refilter()
+
 		// clear one character from the keyword
 		case tea.KeyBackspace:
 			u.backspace()
@@ -170,6 +238,10 @@ func (u *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// add a character to the keyword
 		case tea.KeyRunes:
 			str := msg.String()
+			if str == "?" && !msg.Paste && u.Keyword == "" {
+				u.ShowsHelp = true
+				return u, nil
+			}
 			if !msg.Paste {
 				for _, r := range str {
 					u.addCharacter(string(r))
@@ -198,6 +270,38 @@ func (u *UI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return u, nil
 }
 
+// moveTo moves the cursor to the position in filtered list, within the first and the last.
+func (u *UI) moveTo(position int) {
+	if len(u.Filtered.Order) == 0 {
+		return
+	}
+	position = max(0, min(position, len(u.Filtered.Order)-1))
+	u.Filtered.Cursor = position
+	u.Cursor = u.Filtered.Order[position]
+}
+
+// moveToChoice moves the cursor to the choice if it is in filtered list, or to the position otherwise.
+func (u *UI) moveToChoice(choice int, position int) {
+	for p, i := range u.Filtered.Order {
+		if i == choice {
+			u.moveTo(p)
+			return
+		}
+	}
+	u.moveTo(position)
+}
+
+// refilter filters the choices again by the keyword, keeping the history for backspace.
+func (u *UI) refilter() {
+	keyword := u.Keyword
+	for u.Keyword != "" {
+		u.backspace()
+	}
+	for _, r := range keyword {
+		u.addCharacter(string(r))
+	}
+}
+
 func (u *UI) backspace() {
 	if len(u.Keyword) == 0 {
 		return
@@ -207,57 +311,46 @@ func (u *UI) backspace() {
 	keywordRunes = keywordRunes[:len(keywordRunes)-1]
 	u.Keyword = string(keywordRunes)
 	u.Filtered = u.Filtered.Prev
-	cnt := 0
-	for i := range u.Choices {
-		if _, ok := u.Filtered.Choices[i]; !ok {
-			continue
-		}
-		if cnt == u.Filtered.Cursor {
-			u.Cursor = i
-			break
-		}
-		cnt++
-	}
+	// the cursor stays on the same choice, which is usually also in the previous list
+	u.moveToChoice(u.Cursor, u.Filtered.Cursor)
 }
 
 func (u *UI) addCharacter(c string) {
 	u.Keyword += c
 	u.Filtered = &Filtered{
-		Choices: make(map[int]struct{}),
-		Prev:    u.Filtered,
+		Choices:   make(map[int]struct{}),
+		Order:     []int{},
+		Positions: make(map[int][]int),
+		Prev:      u.Filtered,
 	}
 
-	tmpCursor := u.Cursor
-	for i, choice := range u.Choices {
-		lk := strings.ToLower(u.Keyword)
-		lc := strings.ToLower(choice)
-		contains := strings.Contains(lc, lk)
-
-		fLen := len(u.Filtered.Choices)
-		if contains && fLen != 0 && fLen <= u.Filtered.Prev.Cursor {
-			u.Filtered.Cursor++
-			u.Cursor = i
-		}
-
-		switch {
-		case contains:
-			u.Filtered.Choices[i] = struct{}{}
-			tmpCursor = i
-		case u.Cursor == i && u.Cursor < len(u.Choices)-1:
-			u.Cursor++
-		case u.Cursor == i:
-			u.Cursor = tmpCursor
+	matchLabel := newMatchFunc(u.FilterMode, u.Keyword)
+	scores := make(map[int]int)
+	for i := range u.Choices {
+		m, ok := matchLabel(u.label(i))
+		if !ok {
+			continue
 		}
+		u.Filtered.Choices[i] = struct{}{}
+		u.Filtered.Order = append(u.Filtered.Order, i)
+		u.Filtered.Positions[i] = m.positions
+		scores[i] = m.score
 	}
 
-	if len(u.Filtered.Choices) == 0 {
+	if u.FilterMode == FilterFuzzy {
+		// better scores first, and then shorter labels, keeping the original order for the same ones
+		sort.SliceStable(u.Filtered.Order, func(a, b int) bool {
+			i, j := u.Filtered.Order[a], u.Filtered.Order[b]
+			if scores[i] != scores[j] {
+				return scores[i] > scores[j]
+			}
+			return len(u.label(i)) < len(u.label(j))
+		})
+		// the best match gets the cursor, as the order changes by each character
+		u.moveTo(0)
 		return
 	}
-	f := u.Filtered
-	for f.Prev != nil {
-		f.Prev.Cursor = u.Filtered.Cursor
-		f = f.Prev
-	}
+	u.moveToChoice(u.Cursor, 0)
 }
 
 func (u *UI) View() string {
@@ -275,15 +368,23 @@ func (u *UI) View() string {
 
 	s += bold.Sprintln(u.Keyword)
 
-	s += color.CyanString(" [Use arrows to move, space to select, <right> to all, <left> to none, type to filter]")
+	s += color.CyanString(" [Use arrows to move, space to select, <right> to all, <left> to none, type to filter, ? for help]")
 	s += "\n"
 
-	var contents []string
-	for i, choice := range u.Choices {
-		if _, ok := u.Filtered.Choices[i]; !ok {
-			continue
+	if u.ShowsHelp {
+		return s + helpText
+	}
+
+	status := fmt.Sprintf(" %d selected / %d shown (filter: %s)", len(u.Selected), len(u.Filtered.Order), u.FilterMode)
+	if u.FilterMode == FilterRegex {
+		if _, err := regexp.Compile(u.Keyword); err != nil {
+			status += color.RedString(" invalid regular expression")
 		}
+	}
+	s += status + "\n"
 
+	var contents []string
+	for _, i := range u.Filtered.Order {
 		cursor := " " // no cursor
 		if u.Cursor == i {
 			cursor = color.CyanString(bold.Sprint(">")) // cursor!
@@ -294,7 +395,7 @@ func (u *UI) View() string {
 			checked = color.GreenString("[x]") // selected!
 		}
 
-		contents = append(contents, fmt.Sprintf("%s %s %s\n", cursor, checked, choice))
+		contents = append(contents, fmt.Sprintf("%s %s %s\n", cursor, checked, highlight(u.label(i), u.Filtered.Positions[i])))
 	}
 
 	if len(contents) > SelectionPageSize {
@@ -311,3 +412,25 @@ func (u *UI) View() string {
 	s += strings.Join(contents, "")
 	return s
 }
+
+// highlight emphasizes the characters of the label at the positions.
+func highlight(label string, positions []int) string {
+	if len(positions) == 0 {
+		return label
+	}
+	matched := make(map[int]struct{}, len(positions))
+	for _, p := range positions {
+		matched[p] = struct{}{}
+	}
+
+	emphasis := color.New(color.FgYellow, color.Bold)
+	var b strings.Builder
+	for i, r := range []rune(label) {
+		if _, ok := matched[i]; ok {
+			b.WriteString(emphasis.Sprint(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,342 @@
+package io
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+)
+
+func newTestUI(choices []string, labels []string) *UI {
+	ui := NewUIWithLabels(choices, labels, []string{"Select."})
+	ui.Init()
+	return ui
+}
+
+func typeKeyword(ui *UI, keyword string) {
+	ui.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keyword)})
+}
+
+func TestUI_Labels(t *testing.T) {
+	ui := newTestUI(
+		[]string{"us-east-1", "ap-northeast-1", "eu-west-1"},
+		[]string{"us-east-1 (aws) - 42 functions", "ap-northeast-1 (aws) - 0 functions", "eu-west-1 (aws) - failed"},
+	)
+
+	view := ui.View()
+	if !strings.Contains(view, "us-east-1 (aws) - 42 functions") {
+		t.Errorf("View() does not show the label: %s", view)
+	}
+
+	// the labels are filtered instead of the choices
+	typeKeyword(ui, "failed")
+	if _, ok := ui.Filtered.Choices[2]; !ok || len(ui.Filtered.Choices) != 1 {
+		t.Errorf("Filtered.Choices = %v, want only eu-west-1", ui.Filtered.Choices)
+	}
+
+	ui.Update(tea.KeyMsg{Type: tea.KeySpace})
+	if _, ok := ui.Selected[2]; !ok {
+		t.Errorf("Selected = %v, want eu-west-1", ui.Selected)
+	}
+}
+
+func TestUI_WithoutLabels(t *testing.T) {
+	ui := newTestUI([]string{"nodejs20.x", "python3.12"}, nil)
+
+	typeKeyword(ui, "py")
+	if _, ok := ui.Filtered.Choices[1]; !ok || len(ui.Filtered.Choices) != 1 {
+		t.Errorf("Filtered.Choices = %v, want only python3.12", ui.Filtered.Choices)
+	}
+	if !strings.Contains(ui.View(), "python3.12") {
+		t.Errorf("View() does not show the choice: %s", ui.View())
+	}
+}
+
+func pressKeys(ui *UI, keys ...tea.KeyType) {
+	for _, key := range keys {
+		ui.Update(tea.KeyMsg{Type: key})
+	}
+}
+
+func newNumberedUI(n int) *UI {
+	choices := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		choices = append(choices, fmt.Sprintf("choice%03d", i))
+	}
+	return newTestUI(choices, nil)
+}
+
+func TestUI_PageAndHomeEnd(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []tea.KeyType
+		wantCursor int
+	}{
+		{
+			name:       "PgDown moves the cursor by a page",
+			keys:       []tea.KeyType{tea.KeyPgDown},
+			wantCursor: SelectionPageSize,
+		},
+		{
+			name:       "PgDown stops at the last",
+			keys:       []tea.KeyType{tea.KeyPgDown, tea.KeyPgDown, tea.KeyPgDown},
+			wantCursor: 49,
+		},
+		{
+			name:       "PgUp moves the cursor back by a page",
+			keys:       []tea.KeyType{tea.KeyPgDown, tea.KeyPgDown, tea.KeyPgUp},
+			wantCursor: SelectionPageSize,
+		},
+		{
+			name:       "PgUp stops at the first",
+			keys:       []tea.KeyType{tea.KeyDown, tea.KeyPgUp},
+			wantCursor: 0,
+		},
+		{
+			name:       "End moves the cursor to the last",
+			keys:       []tea.KeyType{tea.KeyEnd},
+			wantCursor: 49,
+		},
+		{
+			name:       "Home moves the cursor to the first",
+			keys:       []tea.KeyType{tea.KeyEnd, tea.KeyHome},
+			wantCursor: 0,
+		},
+		{
+			name:       "Down after End wraps to the first",
+			keys:       []tea.KeyType{tea.KeyEnd, tea.KeyDown},
+			wantCursor: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := newNumberedUI(50)
+			pressKeys(ui, tt.keys...)
+			if ui.Cursor != tt.wantCursor || ui.Filtered.Cursor != tt.wantCursor {
+				t.Errorf("Cursor = %d, Filtered.Cursor = %d, want %d", ui.Cursor, ui.Filtered.Cursor, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestUI_EndInFilteredList(t *testing.T) {
+	ui := newTestUI([]string{"nodejs18.x", "python3.9", "nodejs20.x", "python3.12", "go1.x"}, nil)
+
+	typeKeyword(ui, "python")
+	pressKeys(ui, tea.KeyEnd)
+	if ui.Cursor != 3 || ui.Filtered.Cursor != 1 {
+		t.Errorf("Cursor = %d, Filtered.Cursor = %d, want 3 and 1", ui.Cursor, ui.Filtered.Cursor)
+	}
+
+	pressKeys(ui, tea.KeyHome)
+	if ui.Cursor != 1 || ui.Filtered.Cursor != 0 {
+		t.Errorf("Cursor = %d, Filtered.Cursor = %d, want 1 and 0", ui.Cursor, ui.Filtered.Cursor)
+	}
+}
+
+func TestUI_InvertSelection(t *testing.T) {
+	ui := newTestUI([]string{"nodejs18.x", "python3.9", "nodejs20.x", "python3.12"}, nil)
+
+	// select nodejs18.x, then invert only the shown nodejs values
+	pressKeys(ui, tea.KeySpace)
+	typeKeyword(ui, "nodejs")
+	pressKeys(ui, tea.KeyCtrlR)
+
+	want := map[int]struct{}{2: {}}
+	if !reflect.DeepEqual(ui.Selected, want) {
+		t.Errorf("Selected = %v, want %v", ui.Selected, want)
+	}
+
+	pressKeys(ui, tea.KeyCtrlW, tea.KeyCtrlR)
+	want = map[int]struct{}{0: {}, 1: {}, 3: {}}
+	if !reflect.DeepEqual(ui.Selected, want) {
+		t.Errorf("Selected = %v, want %v", ui.Selected, want)
+	}
+}
+
+func TestUI_FilterModes(t *testing.T) {
+	choices := []string{"nodejs18.x", "python3.9", "nodejs20.x", "python3.12", "provided.al2"}
+
+	tests := []struct {
+		name     string
+		switches int
+		keyword  string
+		wantMode FilterMode
+		want     map[int]struct{}
+	}{
+		{
+			name:     "substring",
+			switches: 0,
+			keyword:  "3.1",
+			wantMode: FilterSubstring,
+			want:     map[int]struct{}{3: {}},
+		},
+		{
+			name:     "fuzzy",
+			switches: 1,
+			keyword:  "py312",
+			wantMode: FilterFuzzy,
+			want:     map[int]struct{}{3: {}},
+		},
+		{
+			name:     "regex",
+			switches: 2,
+			keyword:  "^nodejs(18|20)",
+			wantMode: FilterRegex,
+			want:     map[int]struct{}{0: {}, 2: {}},
+		},
+		{
+			name:     "invalid regex matches nothing",
+			switches: 2,
+			keyword:  "node[",
+			wantMode: FilterRegex,
+			want:     map[int]struct{}{},
+		},
+		{
+			name:     "back to substring",
+			switches: 3,
+			keyword:  "pro",
+			wantMode: FilterSubstring,
+			want:     map[int]struct{}{4: {}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := newTestUI(choices, nil)
+			for i := 0; i < tt.switches; i++ {
+				pressKeys(ui, tea.KeyCtrlF)
+			}
+			typeKeyword(ui, tt.keyword)
+			if ui.FilterMode != tt.wantMode {
+				t.Errorf("FilterMode = %v, want %v", ui.FilterMode, tt.wantMode)
+			}
+			if !reflect.DeepEqual(ui.Filtered.Choices, tt.want) {
+				t.Errorf("Filtered.Choices = %v, want %v", ui.Filtered.Choices, tt.want)
+			}
+		})
+	}
+}
+
+func TestUI_SwitchFilterModeKeepsKeyword(t *testing.T) {
+	ui := newTestUI([]string{"nodejs18.x", "python3.9", "python3.12"}, nil)
+
+	typeKeyword(ui, "p39")
+	if len(ui.Filtered.Choices) != 0 {
+		t.Errorf("Filtered.Choices = %v, want none in substring mode", ui.Filtered.Choices)
+	}
+
+	pressKeys(ui, tea.KeyCtrlF)
+	if ui.Keyword != "p39" || !reflect.DeepEqual(ui.Filtered.Choices, map[int]struct{}{1: {}}) {
+		t.Errorf("Keyword = %q, Filtered.Choices = %v, want p39 and python3.9", ui.Keyword, ui.Filtered.Choices)
+	}
+
+	// backspace still goes back through the keyword
+	pressKeys(ui, tea.KeyBackspace, tea.KeyBackspace)
+	if ui.Keyword != "p" || len(ui.Filtered.Choices) != 2 {
+		t.Errorf("Keyword = %q, Filtered.Choices = %v, want p and the python values", ui.Keyword, ui.Filtered.Choices)
+	}
+	pressKeys(ui, tea.KeyBackspace)
+	if ui.Keyword != "" || len(ui.Filtered.Choices) != 3 {
+		t.Errorf("Keyword = %q, Filtered.Choices = %v, want all", ui.Keyword, ui.Filtered.Choices)
+	}
+}
+
+func TestUI_Help(t *testing.T) {
+	ui := newTestUI([]string{"nodejs18.x", "python3.9"}, nil)
+
+	typeKeyword(ui, "?")
+	if !ui.ShowsHelp || ui.Keyword != "" {
+		t.Fatalf("ShowsHelp = %v, Keyword = %q, want the help shown", ui.ShowsHelp, ui.Keyword)
+	}
+	if view := ui.View(); !strings.Contains(view, "ctrl+r") || strings.Contains(view, "nodejs18.x") {
+		t.Errorf("View() = %s, want the help instead of the choices", view)
+	}
+
+	// any key closes the help without being handled
+	pressKeys(ui, tea.KeySpace)
+	if ui.ShowsHelp || len(ui.Selected) != 0 {
+		t.Errorf("ShowsHelp = %v, Selected = %v, want the help closed", ui.ShowsHelp, ui.Selected)
+	}
+
+	// ? is a character of the keyword if the keyword is not empty
+	pressKeys(ui, tea.KeyCtrlF, tea.KeyCtrlF)
+	typeKeyword(ui, "3.9?")
+	if ui.ShowsHelp || ui.Keyword != "3.9?" {
+		t.Errorf("ShowsHelp = %v, Keyword = %q, want the keyword", ui.ShowsHelp, ui.Keyword)
+	}
+}
+
+func TestUI_Status(t *testing.T) {
+	ui := newTestUI([]string{"nodejs18.x", "python3.9", "python3.12"}, nil)
+
+	typeKeyword(ui, "python")
+	pressKeys(ui, tea.KeyRight)
+
+	if view := ui.View(); !strings.Contains(view, "2 selected / 2 shown (filter: substring)") {
+		t.Errorf("View() = %s, want the status", view)
+	}
+}
+
+func TestUI_FuzzyRanking(t *testing.T) {
+	ui := newTestUI([]string{"nodejs18.x", "provided.al2023", "python3.9", "go1.x"}, nil)
+
+	pressKeys(ui, tea.KeyCtrlF)
+	typeKeyword(ui, "p3")
+
+	if want := []int{2, 1}; !reflect.DeepEqual(ui.Filtered.Order, want) {
+		t.Errorf("Filtered.Order = %v, want %v", ui.Filtered.Order, want)
+	}
+	if ui.Cursor != 2 || ui.Filtered.Cursor != 0 {
+		t.Errorf("Cursor = %d, Filtered.Cursor = %d, want the best match", ui.Cursor, ui.Filtered.Cursor)
+	}
+
+	view := ui.View()
+	if strings.Index(view, "python3.9") > strings.Index(view, "provided.al2023") {
+		t.Errorf("View() = %s, want python3.9 before provided.al2023", view)
+	}
+
+	// the cursor stays on the choice by backspace, and the original order is restored
+	pressKeys(ui, tea.KeyDown, tea.KeyBackspace)
+	if ui.Keyword != "p" || ui.Cursor != 1 {
+		t.Errorf("Keyword = %q, Cursor = %d, want p and provided.al2023", ui.Keyword, ui.Cursor)
+	}
+	pressKeys(ui, tea.KeyBackspace)
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(ui.Filtered.Order, want) || ui.Cursor != 1 || ui.Filtered.Cursor != 1 {
+		t.Errorf("Filtered.Order = %v, Cursor = %d, Filtered.Cursor = %d, want all and provided.al2023", ui.Filtered.Order, ui.Cursor, ui.Filtered.Cursor)
+	}
+}
+
+func TestUI_Highlight(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	ui := newTestUI([]string{"nodejs18.x", "python3.9"}, nil)
+	pressKeys(ui, tea.KeyCtrlF)
+	typeKeyword(ui, "py39")
+
+	emphasis := color.New(color.FgYellow, color.Bold)
+	want := emphasis.Sprint("p") + emphasis.Sprint("y") + "thon" + emphasis.Sprint("3") + "." + emphasis.Sprint("9")
+	if view := ui.View(); !strings.Contains(view, want) {
+		t.Errorf("View() = %q, want the matched characters highlighted", view)
+	}
+}
+
+func TestUI_Preselect(t *testing.T) {
+	ui := newTestUI([]string{"ap-northeast-1", "us-east-1", "us-west-2"}, nil)
+	ui.Preselect([]string{"us-west-2", "eu-west-1", "ap-northeast-1"})
+
+	want := map[int]struct{}{0: {}, 2: {}}
+	if !reflect.DeepEqual(ui.Selected, want) {
+		t.Errorf("Selected = %v, want %v", ui.Selected, want)
+	}
+
+	// the preselected choices can be deselected
+	pressKeys(ui, tea.KeySpace)
+	if _, ok := ui.Selected[0]; ok {
+		t.Errorf("Selected = %v, want ap-northeast-1 deselected", ui.Selected)
+	}
+}
@@ -0,0 +1,48 @@
+package io
+
+import (
+	"bytes"
+
+	"gopkg.in/yaml.v3"
+)
+
+type YAMLRenderer struct{}
+
+var _ Renderer = (*YAMLRenderer)(nil)
+
+// Render renders the result as a YAML sequence of mappings, keeping the order of the columns.
+// Numbers and lists are kept as they are, and empty values are null.
+func (r *YAMLRenderer) Render(result *Result) ([]byte, error) {
+	header := result.Header()
+
+	root := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, record := range result.Records() {
+		mapping := &yaml.Node{Kind: yaml.MappingNode}
+		for i, h := range header {
+			value := &yaml.Node{}
+			if err := value.Encode(record[i]); err != nil {
+				return nil, err
+			}
+			mapping.Content = append(mapping.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: h},
+				value,
+			)
+		}
+		root.Content = append(root.Content, mapping)
+	}
+	if len(root.Content) == 0 {
+		root.Style = yaml.FlowStyle
+	}
+
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
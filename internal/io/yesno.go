@@ -0,0 +1,74 @@
+package io
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+)
+
+// YesNo is the model to confirm yes or no. Enter answers yes.
+type YesNo struct {
+	Label      string
+	Answer     bool
+	IsAnswered bool
+	IsCanceled bool
+}
+
+var _ tea.Model = (*YesNo)(nil)
+
+func NewYesNo(label string) *YesNo {
+	return &YesNo{Label: label}
+}
+
+func (y *YesNo) Init() tea.Cmd {
+	return nil
+}
+
+func (y *YesNo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return y, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEnter:
+		y.answer(true)
+		return y, tea.Quit
+
+	case tea.KeyCtrlC, tea.KeyEsc:
+		y.IsCanceled = true
+		return y, tea.Quit
+
+	case tea.KeyRunes:
+		switch strings.ToLower(string(keyMsg.Runes)) {
+		case "y":
+			y.answer(true)
+			return y, tea.Quit
+		case "n":
+			y.answer(false)
+			return y, tea.Quit
+		}
+	}
+
+	return y, nil
+}
+
+func (y *YesNo) answer(answer bool) {
+	y.Answer = answer
+	y.IsAnswered = true
+}
+
+func (y *YesNo) View() string {
+	s := color.CyanString("? ") + color.New(color.Bold).Sprintf("%s (Y/n) ", y.Label)
+	switch {
+	case y.IsAnswered && y.Answer:
+		return s + color.CyanString("Yes") + "\n"
+	case y.IsAnswered:
+		return s + color.CyanString("No") + "\n"
+	case y.IsCanceled:
+		return s + "\n"
+	default:
+		return s
+	}
+}
@@ -0,0 +1,16 @@
+package ownership
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	fmt.Println()
+	fmt.Println("=====================================")
+	fmt.Println("======= Start Test: ownership =======")
+	fmt.Println("=====================================")
+	goleak.VerifyTestMain(m)
+}
@@ -0,0 +1,76 @@
+// Package ownership resolves the owners of functions by function name prefixes, as a fallback for functions
+// without an ownership tag.
+package ownership
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unowned is the owner of functions with neither an ownership tag nor a matching name prefix.
+const Unowned = "unowned"
+
+// Mapping maps function name prefixes to owners, such as "orders-" to "team-orders".
+type Mapping struct {
+	Prefixes map[string]string `json:"prefixes" yaml:"prefixes"`
+}
+
+// Load reads the mapping from a JSON (.json) or YAML (.yaml, .yml) file. If the path is empty, it returns an empty mapping.
+func Load(path string) (*Mapping, error) {
+	mapping := &Mapping{Prefixes: map[string]string{}}
+	if path == "" {
+		return mapping, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the owner mapping: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, mapping)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, mapping)
+	default:
+		return nil, fmt.Errorf("unsupported owner mapping file: %s (use .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner mapping %s: %w", path, err)
+	}
+	return mapping, mapping.validate()
+}
+
+func (m *Mapping) validate() error {
+	if len(m.Prefixes) == 0 {
+		return fmt.Errorf("owner mapping has no prefixes")
+	}
+	for prefix, owner := range m.Prefixes {
+		if prefix == "" {
+			return fmt.Errorf("owner mapping has an empty prefix")
+		}
+		if owner == "" {
+			return fmt.Errorf("owner mapping has no owner for the prefix: %s", prefix)
+		}
+	}
+	return nil
+}
+
+// Owner returns the owner of the longest prefix of the function name, or false if no prefixes match.
+func (m *Mapping) Owner(functionName string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	owner, longest := "", -1
+	for prefix, o := range m.Prefixes {
+		if strings.HasPrefix(functionName, prefix) && len(prefix) > longest {
+			owner, longest = o, len(prefix)
+		}
+	}
+	return owner, longest >= 0
+}
@@ -0,0 +1,109 @@
+package ownership
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Load success with JSON",
+			path: writeFile("owners.json", `{"prefixes": {"orders-": "team-orders", "billing-": "team-billing"}}`),
+			want: map[string]string{"orders-": "team-orders", "billing-": "team-billing"},
+		},
+		{
+			name: "Load success with YAML",
+			path: writeFile("owners.yaml", "prefixes:\n  orders-: team-orders\n"),
+			want: map[string]string{"orders-": "team-orders"},
+		},
+		{
+			name: "Load success with empty path returns an empty mapping",
+			path: "",
+			want: map[string]string{},
+		},
+		{
+			name:    "Load fail with unsupported extension",
+			path:    writeFile("owners.txt", "orders-=team-orders"),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with invalid YAML",
+			path:    writeFile("invalid.yml", "prefixes: [\n"),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with no prefixes",
+			path:    writeFile("empty.json", `{"prefixes": {}}`),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with an empty owner",
+			path:    writeFile("noowner.json", `{"prefixes": {"orders-": ""}}`),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with a missing file",
+			path:    filepath.Join(dir, "missing.json"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.Prefixes, tt.want) {
+				t.Errorf("Load().Prefixes = %v, want %v", got.Prefixes, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapping_Owner(t *testing.T) {
+	mapping := &Mapping{
+		Prefixes: map[string]string{
+			"orders-":     "team-orders",
+			"orders-api-": "team-api",
+		},
+	}
+	tests := []struct {
+		functionName string
+		want         string
+		wantOk       bool
+	}{
+		{functionName: "orders-worker", want: "team-orders", wantOk: true},
+		{functionName: "orders-api-handler", want: "team-api", wantOk: true},
+		{functionName: "Orders-worker", want: "", wantOk: false},
+		{functionName: "billing-worker", want: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.functionName, func(t *testing.T) {
+			got, ok := mapping.Owner(tt.functionName)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Mapping.Owner() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package runtimecatalog provides the runtime values offered for selection, independent of the runtime enum
+// of the linked AWS SDK. The default catalog is embedded in the binary and can be replaced by a local file.
+package runtimecatalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-to-k/lamver/pkg/runtimeversion"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed runtimes.json
+var defaultCatalog []byte
+
+const (
+	ArchitectureX8664 = "x86_64"
+	ArchitectureArm64 = "arm64"
+)
+
+// Catalog is a list of runtime values.
+type Catalog struct {
+	Runtimes []Runtime `json:"runtimes" yaml:"runtimes"`
+}
+
+// Runtime is a runtime value in the catalog, such as "nodejs20.x".
+type Runtime struct {
+	Name string `json:"name" yaml:"name"`
+	// Deprecation is the date (YYYY-MM-DD) when Lambda deprecates the runtime. It is empty if not announced.
+	Deprecation string `json:"deprecation,omitempty" yaml:"deprecation,omitempty"`
+	// Architectures are the architectures (x86_64 or arm64) that the runtime supports. Empty means both.
+	Architectures []string `json:"architectures,omitempty" yaml:"architectures,omitempty"`
+}
+
+// Default returns the catalog embedded in the binary.
+func Default() (*Catalog, error) {
+	catalog := &Catalog{}
+	if err := json.Unmarshal(defaultCatalog, catalog); err != nil {
+		return nil, fmt.Errorf("invalid embedded runtime catalog: %w", err)
+	}
+	return catalog, catalog.validate()
+}
+
+// Load reads the catalog from a JSON (.json) or YAML (.yaml, .yml) file. If the path is empty, it returns the default catalog.
+func Load(path string) (*Catalog, error) {
+	if path == "" {
+		return Default()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the runtime catalog: %w", err)
+	}
+
+	catalog := &Catalog{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, catalog)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, catalog)
+	default:
+		return nil, fmt.Errorf("unsupported runtime catalog file: %s (use .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid runtime catalog %s: %w", path, err)
+	}
+	return catalog, catalog.validate()
+}
+
+func (c *Catalog) validate() error {
+	if len(c.Runtimes) == 0 {
+		return fmt.Errorf("runtime catalog has no runtimes")
+	}
+	seen := make(map[string]struct{}, len(c.Runtimes))
+	for _, runtime := range c.Runtimes {
+		if runtime.Name == "" {
+			return fmt.Errorf("runtime catalog has a runtime without a name")
+		}
+		if _, ok := seen[runtime.Name]; ok {
+			return fmt.Errorf("runtime catalog has a duplicate runtime: %s", runtime.Name)
+		}
+		seen[runtime.Name] = struct{}{}
+		if runtime.Deprecation != "" {
+			if _, err := time.Parse(time.DateOnly, runtime.Deprecation); err != nil {
+				return fmt.Errorf("runtime catalog has an invalid deprecation date of %s: %s (use YYYY-MM-DD)", runtime.Name, runtime.Deprecation)
+			}
+		}
+		for _, architecture := range runtime.Architectures {
+			if architecture != ArchitectureX8664 && architecture != ArchitectureArm64 {
+				return fmt.Errorf("runtime catalog has an invalid architecture of %s: %s (use %s or %s)", runtime.Name, architecture, ArchitectureX8664, ArchitectureArm64)
+			}
+		}
+	}
+	return nil
+}
+
+// Names returns the runtime values sorted by the name and the version.
+func (c *Catalog) Names() []string {
+	names := make([]string, 0, len(c.Runtimes))
+	for _, runtime := range c.Runtimes {
+		names = append(names, runtime.Name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return runtimeversion.Less(names[i], names[j])
+	})
+	return names
+}
+
+// Contains reports whether the runtime value is in the catalog.
+func (c *Catalog) Contains(name string) bool {
+	for _, runtime := range c.Runtimes {
+		if runtime.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Unknown returns the runtime values not in the catalog, keeping the order.
+func (c *Catalog) Unknown(names []string) []string {
+	unknown := []string{}
+	for _, name := range names {
+		if !c.Contains(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// Deprecation returns the deprecation date of the runtime value. It is zero if the runtime value is not in the catalog
+// or its deprecation is not announced.
+func (c *Catalog) Deprecation(name string) time.Time {
+	for _, runtime := range c.Runtimes {
+		if runtime.Name == name && runtime.Deprecation != "" {
+			// the date is validated on loading
+			date, _ := time.Parse(time.DateOnly, runtime.Deprecation)
+			return date
+		}
+	}
+	return time.Time{}
+}
+
+// SupportsArchitecture reports whether the runtime value supports the architecture.
+// It is false for runtime values not in the catalog, as their support is unknown.
+func (c *Catalog) SupportsArchitecture(name string, architecture string) bool {
+	for _, runtime := range c.Runtimes {
+		if runtime.Name == name {
+			return len(runtime.Architectures) == 0 || slices.Contains(runtime.Architectures, architecture)
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package runtimecatalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDefault(t *testing.T) {
+	catalog, err := Default()
+	if err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	for _, name := range []string{"nodejs20.x", "python3.12", "java21", "provided.al2023"} {
+		if !catalog.Contains(name) {
+			t.Errorf("Default() does not contain %s", name)
+		}
+	}
+	for _, name := range []string{"nodejs4.3", "nodejs4.3-edge", "dotnetcore1.0"} {
+		if catalog.Contains(name) {
+			t.Errorf("Default() contains %s", name)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Load success with JSON",
+			path: writeFile("catalog.json", `{"runtimes": [{"name": "python3.13"}, {"name": "nodejs22.x"}, {"name": "python3.9"}]}`),
+			want: []string{"nodejs22.x", "python3.9", "python3.13"},
+		},
+		{
+			name: "Load success with YAML",
+			path: writeFile("catalog.yaml", "runtimes:\n  - name: nodejs26.x\n  - name: nodejs24.x\n"),
+			want: []string{"nodejs24.x", "nodejs26.x"},
+		},
+		{
+			name: "Load success with empty path returns the default catalog",
+			path: "",
+		},
+		{
+			name:    "Load fail with unsupported extension",
+			path:    writeFile("catalog.txt", "nodejs22.x"),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with invalid JSON",
+			path:    writeFile("invalid.json", `{"runtimes": [`),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with no runtimes",
+			path:    writeFile("empty.yml", "runtimes: []\n"),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with a runtime without a name",
+			path:    writeFile("noname.json", `{"runtimes": [{"name": ""}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with duplicate runtimes",
+			path:    writeFile("duplicate.json", `{"runtimes": [{"name": "java21"}, {"name": "java21"}]}`),
+			wantErr: true,
+		},
+		{
+			name: "Load success with deprecation dates",
+			path: writeFile("deprecation.yaml", "runtimes:\n  - name: nodejs18.x\n    deprecation: \"2025-09-01\"\n  - name: nodejs22.x\n"),
+			want: []string{"nodejs18.x", "nodejs22.x"},
+		},
+		{
+			name:    "Load fail with an invalid deprecation date",
+			path:    writeFile("invalid-deprecation.json", `{"runtimes": [{"name": "nodejs18.x", "deprecation": "2025/09/01"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with an invalid architecture",
+			path:    writeFile("invalid-architecture.yaml", "runtimes:\n  - name: go1.x\n    architectures: [amd64]\n"),
+			wantErr: true,
+		},
+		{
+			name:    "Load fail with a missing file",
+			path:    filepath.Join(dir, "missing.json"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.want == nil {
+				defaultCatalog, _ := Default()
+				tt.want = defaultCatalog.Names()
+			}
+			if !reflect.DeepEqual(got.Names(), tt.want) {
+				t.Errorf("Load().Names() = %v, want %v", got.Names(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_Names(t *testing.T) {
+	catalog := &Catalog{
+		Runtimes: []Runtime{
+			{Name: "python3.10"},
+			{Name: "provided.al2"},
+			{Name: "nodejs8.10"},
+			{Name: "python3.9"},
+			{Name: "provided"},
+			{Name: "nodejs18.x"},
+		},
+	}
+	want := []string{"nodejs8.10", "nodejs18.x", "provided", "provided.al2", "python3.9", "python3.10"}
+	if got := catalog.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog.Names() = %v, want %v", got, want)
+	}
+}
+
+func TestCatalog_Unknown(t *testing.T) {
+	catalog := &Catalog{
+		Runtimes: []Runtime{
+			{Name: "nodejs20.x"},
+			{Name: "python3.12"},
+		},
+	}
+	want := []string{"nodejs4.3", "python3.15"}
+	if got := catalog.Unknown([]string{"nodejs4.3", "nodejs20.x", "python3.12", "python3.15"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalog.Unknown() = %v, want %v", got, want)
+	}
+}
+
+func TestCatalog_Deprecation(t *testing.T) {
+	catalog := &Catalog{
+		Runtimes: []Runtime{
+			{Name: "nodejs18.x", Deprecation: "2025-09-01"},
+			{Name: "nodejs22.x"},
+		},
+	}
+	tests := []struct {
+		name string
+		want time.Time
+	}{
+		{name: "nodejs18.x", want: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "nodejs22.x", want: time.Time{}},
+		{name: "nodejs4.3", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Deprecation(tt.name); !got.Equal(tt.want) {
+				t.Errorf("Catalog.Deprecation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_SupportsArchitecture(t *testing.T) {
+	catalog := &Catalog{
+		Runtimes: []Runtime{
+			{Name: "go1.x", Architectures: []string{ArchitectureX8664}},
+			{Name: "provided.al2023"},
+		},
+	}
+	tests := []struct {
+		name         string
+		architecture string
+		want         bool
+	}{
+		{name: "go1.x", architecture: ArchitectureX8664, want: true},
+		{name: "go1.x", architecture: ArchitectureArm64, want: false},
+		{name: "provided.al2023", architecture: ArchitectureArm64, want: true},
+		{name: "nodejs8.10", architecture: ArchitectureX8664, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.architecture, func(t *testing.T) {
+			if got := catalog.SupportsArchitecture(tt.name, tt.architecture); got != tt.want {
+				t.Errorf("Catalog.SupportsArchitecture() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefault_Architectures(t *testing.T) {
+	catalog, err := Default()
+	if err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	for _, name := range []string{"go1.x", "java8", "python3.7", "provided"} {
+		if catalog.SupportsArchitecture(name, ArchitectureArm64) {
+			t.Errorf("Default() %s supports arm64, want not", name)
+		}
+	}
+	for _, name := range []string{"java8.al2", "python3.8", "dotnetcore3.1", "nodejs20.x", "provided.al2"} {
+		if !catalog.SupportsArchitecture(name, ArchitectureArm64) {
+			t.Errorf("Default() %s does not support arm64, want supported", name)
+		}
+	}
+}
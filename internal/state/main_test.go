@@ -0,0 +1,16 @@
+package state
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	fmt.Println()
+	fmt.Println("==========================================")
+	fmt.Println("=========== Start Test: state ============")
+	fmt.Println("==========================================")
+	goleak.VerifyTestMain(m)
+}
@@ -0,0 +1,89 @@
+// Package state remembers the last selections of regions, runtime values and a keyword, and the input history,
+// by AWS profile, to preselect and recall them in the next run.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const fileName = "state.json"
+
+// MaxHistory is the number of the latest inputs kept in the history.
+const MaxHistory = 100
+
+// Selection is what the user selected in a run.
+type Selection struct {
+	Regions  []string `json:"regions,omitempty"`
+	Runtimes []string `json:"runtimes,omitempty"`
+	Keyword  string   `json:"keyword,omitempty"`
+	// History is the text entered in the input prompts, the oldest first.
+	History []string `json:"history,omitempty"`
+}
+
+type file struct {
+	Profiles map[string]Selection `json:"profiles"`
+}
+
+// DefaultPath returns the path of the state file in the user config directory, e.g. ~/.config/lamver/state.json.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lamver", fileName), nil
+}
+
+// Load returns the last selection for the profile. It is empty if nothing is remembered yet.
+func Load(path string, profile string) (Selection, error) {
+	f, err := read(path)
+	if err != nil {
+		return Selection{}, err
+	}
+	return f.Profiles[profile], nil
+}
+
+// Save remembers the selection for the profile, keeping those for the other profiles.
+// Only the latest MaxHistory inputs of the history are kept.
+func Save(path string, profile string, selection Selection) error {
+	f, err := read(path)
+	if err != nil {
+		return err
+	}
+	if len(selection.History) > MaxHistory {
+		selection.History = selection.History[len(selection.History)-MaxHistory:]
+	}
+	f.Profiles[profile] = selection
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func read(path string) (*file, error) {
+	f := &file{Profiles: map[string]Selection{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return f, err
+	}
+
+	if err := json.Unmarshal(data, f); err != nil {
+		return f, fmt.Errorf("invalid state file %s: %w", path, err)
+	}
+	if f.Profiles == nil {
+		f.Profiles = map[string]Selection{}
+	}
+	return f, nil
+}
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lamver", "state.json")
+
+	got, err := Load(path, "default")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, Selection{}) {
+		t.Errorf("Load() = %v, want empty before saving", got)
+	}
+
+	dev := Selection{
+		Regions:  []string{"ap-northeast-1", "us-east-1"},
+		Runtimes: []string{"nodejs18.x", "python3.9"},
+		Keyword:  "goto",
+		History:  []string{"api", "goto"},
+	}
+	prod := Selection{
+		Regions:  []string{"us-west-2"},
+		Runtimes: []string{"java11"},
+	}
+	if err := Save(path, "dev", dev); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := Save(path, "prod", prod); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, _ := Load(path, "dev"); !reflect.DeepEqual(got, dev) {
+		t.Errorf("Load(dev) = %v, want %v", got, dev)
+	}
+	if got, _ := Load(path, "prod"); !reflect.DeepEqual(got, prod) {
+		t.Errorf("Load(prod) = %v, want %v", got, prod)
+	}
+
+	// the last selection overwrites the previous one of the same profile
+	dev.Keyword = ""
+	if err := Save(path, "dev", dev); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got, _ := Load(path, "dev"); !reflect.DeepEqual(got, dev) {
+		t.Errorf("Load(dev) = %v, want %v", got, dev)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("permission = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestSave_History(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	history := make([]string, 0, MaxHistory+2)
+	for i := 0; i < MaxHistory+2; i++ {
+		history = append(history, fmt.Sprintf("keyword%d", i))
+	}
+	if err := Save(path, "default", Selection{History: history}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	got, err := Load(path, "default")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.History, history[2:]) {
+		t.Errorf("History = %v, want the latest %d inputs", got.History, MaxHistory)
+	}
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path, "default"); err == nil {
+		t.Error("Load() error = nil, want an error for an invalid file")
+	}
+	if err := Save(path, "default", Selection{}); err == nil {
+		t.Error("Save() error = nil, want an error not to overwrite an invalid file")
+	}
+}
@@ -1,12 +1,63 @@
 package types
 
+import (
+	"time"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
 type LambdaFunctionData struct {
-	Runtime      string
+	Runtime      lambdaTypes.Runtime
 	Region       string
 	FunctionName string
-	LastModified string
+	LastModified time.Time
+	CodeSize     int64
+	FunctionArn  string
+	ConsoleURL   string
+	// Architectures is x86_64 or arm64. An empty value means x86_64, the default of Lambda.
+	Architectures []string
+	// Layers are the ARNs of the layer versions used by the function.
+	Layers []string
+	// VpcID, SubnetIDs and SecurityGroupIDs are empty if the function is not attached to a VPC.
+	VpcID            string
+	SubnetIDs        []string
+	SecurityGroupIDs []string
+	// EnvVariables is the number of the environment variables. It is set only when the function is audited,
+	// and the values of the variables are never kept.
+	EnvVariables int
+	// KMSKeyArn is the customer managed key to encrypt the environment variables, or empty for the AWS managed key.
+	KMSKeyArn string
+	// TracingMode is the X-Ray tracing mode, Active or PassThrough.
+	TracingMode string
+	// SnapStart is the SnapStart setting, PublishedVersions or None.
+	SnapStart           string
+	LogFormat           string
+	LogGroup            string
+	DeadLetterTargetArn string
+	// Invocations, Errors and LastInvoked are set only when invocations are enriched from CloudWatch.
+	Invocations int64
+	Errors      int64
+	LastInvoked time.Time
+	// Triggers is set only when triggers are enriched from event source mappings and the function policy.
+	Triggers []string
+	// FunctionURLAuthType is the most permissive auth type of the function URLs, NONE or AWS_IAM.
+	// It is empty if the function has no function URL, or function URLs are not enriched.
+	FunctionURLAuthType string
+	// CodeSigningConfigArn is set only when code signing configs are enriched.
+	CodeSigningConfigArn string
+	// Tags is set only when the tags are listed for the search, such as by a query with tag:.
+	// It is nil if the tags are not listed, and empty if the function has no tags.
+	Tags map[string]string
+	// EnvFindings is set only when the function is audited.
+	EnvFindings []EnvFinding
+	// Owner and RuntimeDeprecation are set only in the ownership report.
+	// RuntimeDeprecation is zero if the deprecation of the runtime is not announced.
+	Owner              string
+	RuntimeDeprecation time.Time
 }
 
-func GetLambdaFunctionDataKeys() []string {
-	return []string{"Runtime", "Region", "FunctionName", "LastModified"}
+// EnvFinding is an environment variable that looks like a secret. It never holds the value.
+type EnvFinding struct {
+	Name   string
+	Reason string
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -10,11 +11,23 @@ import (
 const DefaultAwsRegion = "us-east-1"
 
 func LoadAWSConfig(ctx context.Context, region string, profile string) (aws.Config, error) {
+	return LoadAWSConfigWithPartition(ctx, region, profile, "")
+}
+
+// LoadAWSConfigWithPartition loads the shared config like LoadAWSConfig. If partition is not empty, the region is
+// validated against it, and the default region of the partition is used when no region is configured.
+func LoadAWSConfigWithPartition(ctx context.Context, region string, profile string, partition string) (aws.Config, error) {
 	var (
 		cfg aws.Config
 		err error
 	)
 
+	if partition != "" {
+		if err := ValidatePartition(partition); err != nil {
+			return cfg, err
+		}
+	}
+
 	if profile != "" {
 		cfg, err = config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(profile))
 	} else {
@@ -29,7 +42,11 @@ func LoadAWSConfig(ctx context.Context, region string, profile string) (aws.Conf
 		cfg.Region = region
 	}
 	if cfg.Region == "" {
-		cfg.Region = DefaultAwsRegion
+		cfg.Region = GetDefaultRegion(partition)
+	}
+
+	if partition != "" && GetPartition(cfg.Region) != partition {
+		return cfg, fmt.Errorf("region %s does not belong to partition %s", cfg.Region, partition)
 	}
 
 	return cfg, nil
@@ -0,0 +1,169 @@
+//go:generate mockgen -source=$GOFILE -destination=cloudwatch_mock.go -package=$GOPACKAGE -write_package_comment=false
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	// MetricDataQueriesLimit is the maximum number of metric queries in a GetMetricData call.
+	MetricDataQueriesLimit = 500
+	// metricPeriodSeconds is one day, so that the last invoked day can be found from the data points.
+	metricPeriodSeconds = 86400
+
+	invocationsQueryIDPrefix = "invocations_"
+	errorsQueryIDPrefix      = "errors_"
+)
+
+// FunctionMetrics is the activity of a function in a period.
+type FunctionMetrics struct {
+	Invocations int64
+	Errors      int64
+	// LastInvoked is the start of the latest day with invocations in UTC. The zero value means no invocations in the period.
+	LastInvoked time.Time
+}
+
+type CloudWatchClient interface {
+	GetFunctionMetricsWithRegion(
+		ctx context.Context,
+		region string,
+		functionNames []string,
+		startTime time.Time,
+		endTime time.Time,
+	) (map[string]FunctionMetrics, error)
+}
+
+type CloudWatch struct {
+	client *cloudwatch.Client
+}
+
+var _ CloudWatchClient = (*CloudWatch)(nil)
+
+func NewCloudWatch(client *cloudwatch.Client) *CloudWatch {
+	return &CloudWatch{
+		client: client,
+	}
+}
+
+// GetFunctionMetricsWithRegion returns the metrics for each function name. Functions without
+// any data points are included with zero values.
+func (c *CloudWatch) GetFunctionMetricsWithRegion(
+	ctx context.Context,
+	region string,
+	functionNames []string,
+	startTime time.Time,
+	endTime time.Time,
+) (map[string]FunctionMetrics, error) {
+	metrics := make(map[string]FunctionMetrics, len(functionNames))
+	for _, name := range functionNames {
+		metrics[name] = FunctionMetrics{}
+	}
+
+	var optFns []func(*cloudwatch.Options)
+	if region != "" {
+		optFns = append(optFns, func(o *cloudwatch.Options) {
+			o.Region = region
+		})
+	}
+
+	// each function needs two queries, for Invocations and Errors
+	batchSize := MetricDataQueriesLimit / 2
+	for start := 0; start < len(functionNames); start += batchSize {
+		end := min(start+batchSize, len(functionNames))
+
+		queries := make([]types.MetricDataQuery, 0, (end-start)*2)
+		for i := start; i < end; i++ {
+			queries = append(queries,
+				newLambdaMetricDataQuery(invocationsQueryIDPrefix+strconv.Itoa(i), "Invocations", functionNames[i]),
+				newLambdaMetricDataQuery(errorsQueryIDPrefix+strconv.Itoa(i), "Errors", functionNames[i]),
+			)
+		}
+
+		var nextToken *string
+		for {
+			input := &cloudwatch.GetMetricDataInput{
+				StartTime:         aws.Time(startTime),
+				EndTime:           aws.Time(endTime),
+				MetricDataQueries: queries,
+				NextToken:         nextToken,
+			}
+
+			output, err := c.client.GetMetricData(ctx, input, optFns...)
+			if err != nil {
+				return metrics, err
+			}
+
+			for _, result := range output.MetricDataResults {
+				if err := addMetricDataResult(metrics, functionNames, result); err != nil {
+					return metrics, err
+				}
+			}
+
+			nextToken = output.NextToken
+			if nextToken == nil {
+				break
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+func newLambdaMetricDataQuery(id string, metricName string, functionName string) types.MetricDataQuery {
+	return types.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &types.MetricStat{
+			Metric: &types.Metric{
+				Namespace:  aws.String("AWS/Lambda"),
+				MetricName: aws.String(metricName),
+				Dimensions: []types.Dimension{
+					{
+						Name:  aws.String("FunctionName"),
+						Value: aws.String(functionName),
+					},
+				},
+			},
+			Period: aws.Int32(metricPeriodSeconds),
+			Stat:   aws.String("Sum"),
+		},
+		ReturnData: aws.Bool(true),
+	}
+}
+
+func addMetricDataResult(metrics map[string]FunctionMetrics, functionNames []string, result types.MetricDataResult) error {
+	id := aws.ToString(result.Id)
+
+	prefix := invocationsQueryIDPrefix
+	if strings.HasPrefix(id, errorsQueryIDPrefix) {
+		prefix = errorsQueryIDPrefix
+	}
+	index, err := strconv.Atoi(strings.TrimPrefix(id, prefix))
+	if err != nil || index < 0 || index >= len(functionNames) {
+		return fmt.Errorf("unexpected metric data query id: %s", id)
+	}
+
+	name := functionNames[index]
+	m := metrics[name]
+	for i, value := range result.Values {
+		count := int64(value)
+		if prefix == errorsQueryIDPrefix {
+			m.Errors += count
+			continue
+		}
+		m.Invocations += count
+		if count > 0 && i < len(result.Timestamps) && result.Timestamps[i].After(m.LastInvoked) {
+			m.LastInvoked = result.Timestamps[i].UTC()
+		}
+	}
+	metrics[name] = m
+
+	return nil
+}
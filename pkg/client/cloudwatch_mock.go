@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cloudwatch.go
+//
+// Generated by this command:
+//
+//	mockgen -source=cloudwatch.go -destination=cloudwatch_mock.go -package=client -write_package_comment=false
+//
+
+package client
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCloudWatchClient is a mock of CloudWatchClient interface.
+type MockCloudWatchClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudWatchClientMockRecorder
+	isgomock struct{}
+}
+
+// MockCloudWatchClientMockRecorder is the mock recorder for MockCloudWatchClient.
+type MockCloudWatchClientMockRecorder struct {
+	mock *MockCloudWatchClient
+}
+
+// NewMockCloudWatchClient creates a new mock instance.
+func NewMockCloudWatchClient(ctrl *gomock.Controller) *MockCloudWatchClient {
+	mock := &MockCloudWatchClient{ctrl: ctrl}
+	mock.recorder = &MockCloudWatchClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudWatchClient) EXPECT() *MockCloudWatchClientMockRecorder {
+	return m.recorder
+}
+
+// GetFunctionMetricsWithRegion mocks base method.
+func (m *MockCloudWatchClient) GetFunctionMetricsWithRegion(ctx context.Context, region string, functionNames []string, startTime, endTime time.Time) (map[string]FunctionMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionMetricsWithRegion", ctx, region, functionNames, startTime, endTime)
+	ret0, _ := ret[0].(map[string]FunctionMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionMetricsWithRegion indicates an expected call of GetFunctionMetricsWithRegion.
+func (mr *MockCloudWatchClientMockRecorder) GetFunctionMetricsWithRegion(ctx, region, functionNames, startTime, endTime any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionMetricsWithRegion", reflect.TypeOf((*MockCloudWatchClient)(nil).GetFunctionMetricsWithRegion), ctx, region, functionNames, startTime, endTime)
+}
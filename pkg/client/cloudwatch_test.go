@@ -0,0 +1,308 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/smithy-go/middleware"
+)
+
+func TestCloudWatch_GetFunctionMetricsWithRegion(t *testing.T) {
+	endTime := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	startTime := endTime.AddDate(0, 0, -30)
+
+	type args struct {
+		ctx                context.Context
+		region             string
+		functionNames      []string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]FunctionMetrics
+		wantErr bool
+	}{
+		{
+			name: "GetFunctionMetricsWithRegion success",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				functionNames: []string{"Function1", "Function2"},
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetMetricDataMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudwatch.GetMetricDataOutput{
+										MetricDataResults: []types.MetricDataResult{
+											{
+												Id: aws.String("invocations_0"),
+												Timestamps: []time.Time{
+													time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+													time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+												},
+												Values: []float64{3, 7},
+											},
+											{
+												Id: aws.String("errors_0"),
+												Timestamps: []time.Time{
+													time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+												},
+												Values: []float64{2},
+											},
+											{
+												Id:         aws.String("invocations_1"),
+												Timestamps: []time.Time{},
+												Values:     []float64{},
+											},
+											{
+												Id:         aws.String("errors_1"),
+												Timestamps: []time.Time{},
+												Values:     []float64{},
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: map[string]FunctionMetrics{
+				"Function1": {
+					Invocations: 10,
+					Errors:      2,
+					LastInvoked: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+				},
+				"Function2": {},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetFunctionMetricsWithRegion with zero invocations in the latest day success",
+			args: args{
+				ctx:           context.Background(),
+				region:        "",
+				functionNames: []string{"Function1"},
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetMetricDataWithZeroMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudwatch.GetMetricDataOutput{
+										MetricDataResults: []types.MetricDataResult{
+											{
+												Id: aws.String("invocations_0"),
+												Timestamps: []time.Time{
+													time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+													time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+												},
+												Values: []float64{0, 1},
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: map[string]FunctionMetrics{
+				"Function1": {
+					Invocations: 1,
+					LastInvoked: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GetFunctionMetricsWithRegion with no functions success",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				functionNames: []string{},
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetMetricDataNotCalledMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("GetMetricDataShouldNotBeCalled")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    map[string]FunctionMetrics{},
+			wantErr: false,
+		},
+		{
+			name: "GetFunctionMetricsWithRegion with unexpected query id fail",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				functionNames: []string{"Function1"},
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetMetricDataUnexpectedIDMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudwatch.GetMetricDataOutput{
+										MetricDataResults: []types.MetricDataResult{
+											{
+												Id: aws.String("invocations_1"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: map[string]FunctionMetrics{
+				"Function1": {},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GetFunctionMetricsWithRegion fail",
+			args: args{
+				ctx:           context.Background(),
+				region:        "us-east-1",
+				functionNames: []string{"Function1"},
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetMetricDataErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudwatch.GetMetricDataOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("GetMetricDataError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: map[string]FunctionMetrics{
+				"Function1": {},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudwatch.NewFromConfig(cfg)
+			cloudWatchClient := NewCloudWatch(client)
+
+			got, err := cloudWatchClient.GetFunctionMetricsWithRegion(tt.args.ctx, tt.args.region, tt.args.functionNames, startTime, endTime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CloudWatch.GetFunctionMetricsWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CloudWatch.GetFunctionMetricsWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloudWatch_GetFunctionMetricsWithRegion_Batch(t *testing.T) {
+	ctx := context.Background()
+	endTime := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	startTime := endTime.AddDate(0, 0, -30)
+
+	functionNames := make([]string, 0, 300)
+	for i := range 300 {
+		functionNames = append(functionNames, "Function"+strconv.Itoa(i))
+	}
+
+	queryCounts := []int{}
+	withAPIOptionsFunc := func(stack *middleware.Stack) error {
+		if err := stack.Initialize.Add(
+			middleware.InitializeMiddlewareFunc(
+				"CountMetricDataQueries",
+				func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+					if v, ok := in.Parameters.(*cloudwatch.GetMetricDataInput); ok {
+						queryCounts = append(queryCounts, len(v.MetricDataQueries))
+					}
+					return next.HandleInitialize(ctx, in)
+				},
+			),
+			middleware.Before,
+		); err != nil {
+			return err
+		}
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"GetMetricDataBatchMock",
+				func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					return middleware.FinalizeOutput{
+						Result: &cloudwatch.GetMetricDataOutput{
+							MetricDataResults: []types.MetricDataResult{
+								{
+									Id:         aws.String("invocations_299"),
+									Timestamps: []time.Time{time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)},
+									Values:     []float64{5},
+								},
+							},
+						},
+					}, middleware.Metadata{}, nil
+				},
+			),
+			middleware.Before,
+		)
+	}
+
+	cfg, err := config.LoadDefaultConfig(
+		ctx,
+		config.WithRegion("ap-northeast-1"),
+		config.WithAPIOptions([]func(*middleware.Stack) error{withAPIOptionsFunc}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cloudWatchClient := NewCloudWatch(cloudwatch.NewFromConfig(cfg))
+
+	got, err := cloudWatchClient.GetFunctionMetricsWithRegion(ctx, "us-east-1", functionNames, startTime, endTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{MetricDataQueriesLimit, 100}; !reflect.DeepEqual(queryCounts, want) {
+		t.Errorf("GetMetricData query counts = %v, want %v", queryCounts, want)
+	}
+	if len(got) != len(functionNames) {
+		t.Errorf("CloudWatch.GetFunctionMetricsWithRegion() returned %d functions, want %d", len(got), len(functionNames))
+	}
+	// the mock returns the same result for each batch
+	if want := int64(10); got["Function299"].Invocations != want {
+		t.Errorf("CloudWatch.GetFunctionMetricsWithRegion() invocations = %d, want %d", got["Function299"].Invocations, want)
+	}
+}
@@ -5,11 +5,29 @@ import (
 	"context"
 	"sort"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 )
 
+const (
+	RegionOptInNotRequired = "opt-in-not-required"
+	RegionOptedIn          = "opted-in"
+	RegionNotOptedIn       = "not-opted-in"
+)
+
+type Region struct {
+	Name        string
+	OptInStatus string
+	Partition   string
+}
+
+// IsEnabled reports whether the region can be called with the current account.
+func (r Region) IsEnabled() bool {
+	return r.OptInStatus != RegionNotOptedIn
+}
+
 type EC2Client interface {
-	DescribeRegions(ctx context.Context) ([]string, error)
+	DescribeRegions(ctx context.Context) ([]Region, error)
 }
 
 type EC2 struct {
@@ -23,9 +41,12 @@ func NewEC2(client *ec2.Client) *EC2 {
 		client: client,
 	}
 }
-func (c *EC2) DescribeRegions(ctx context.Context) ([]string, error) {
-	outputRegions := []string{}
-	input := &ec2.DescribeRegionsInput{}
+
+func (c *EC2) DescribeRegions(ctx context.Context) ([]Region, error) {
+	outputRegions := []Region{}
+	input := &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+	}
 
 	output, err := c.client.DescribeRegions(ctx, input)
 	if err != nil {
@@ -33,9 +54,20 @@ func (c *EC2) DescribeRegions(ctx context.Context) ([]string, error) {
 	}
 
 	for _, region := range output.Regions {
-		outputRegions = append(outputRegions, *region.RegionName)
+		name := aws.ToString(region.RegionName)
+		optInStatus := aws.ToString(region.OptInStatus)
+		if optInStatus == "" {
+			optInStatus = RegionOptInNotRequired
+		}
+		outputRegions = append(outputRegions, Region{
+			Name:        name,
+			OptInStatus: optInStatus,
+			Partition:   GetPartition(name),
+		})
 	}
 
-	sort.Strings(outputRegions)
+	sort.Slice(outputRegions, func(i, j int) bool {
+		return outputRegions[i].Name < outputRegions[j].Name
+	})
 	return outputRegions, nil
 }
@@ -4,6 +4,8 @@
 // Generated by this command:
 //
 //	mockgen -source=ec2.go -destination=ec2_mock.go -package=client -write_package_comment=false
+//
+
 package client
 
 import (
@@ -17,6 +19,7 @@ import (
 type MockEC2Client struct {
 	ctrl     *gomock.Controller
 	recorder *MockEC2ClientMockRecorder
+	isgomock struct{}
 }
 
 // MockEC2ClientMockRecorder is the mock recorder for MockEC2Client.
@@ -37,10 +40,10 @@ func (m *MockEC2Client) EXPECT() *MockEC2ClientMockRecorder {
 }
 
 // DescribeRegions mocks base method.
-func (m *MockEC2Client) DescribeRegions(ctx context.Context) ([]string, error) {
+func (m *MockEC2Client) DescribeRegions(ctx context.Context) ([]Region, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DescribeRegions", ctx)
-	ret0, _ := ret[0].([]string)
+	ret0, _ := ret[0].([]Region)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
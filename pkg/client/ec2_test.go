@@ -21,7 +21,7 @@ func TestEC2_DescribeRegions(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    []string
+		want    []Region
 		wantErr bool
 	}{
 		{
@@ -51,9 +51,9 @@ func TestEC2_DescribeRegions(t *testing.T) {
 					)
 				},
 			},
-			want: []string{
-				"ap-northeast-1",
-				"us-east-1",
+			want: []Region{
+				{Name: "ap-northeast-1", OptInStatus: RegionOptInNotRequired, Partition: PartitionAWS},
+				{Name: "us-east-1", OptInStatus: RegionOptInNotRequired, Partition: PartitionAWS},
 			},
 			wantErr: false,
 		},
@@ -84,9 +84,49 @@ func TestEC2_DescribeRegions(t *testing.T) {
 					)
 				},
 			},
-			want: []string{ // sort by region name
-				"ap-northeast-1",
-				"us-east-1",
+			want: []Region{ // sort by region name
+				{Name: "ap-northeast-1", OptInStatus: RegionOptInNotRequired, Partition: PartitionAWS},
+				{Name: "us-east-1", OptInStatus: RegionOptInNotRequired, Partition: PartitionAWS},
+			},
+			wantErr: false,
+		},
+		{
+			name: "DescribeRegions with opt-in status success",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeRegionsOptInStatusMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeRegionsOutput{
+										Regions: []types.Region{
+											{
+												RegionName:  aws.String("us-east-1"),
+												OptInStatus: aws.String("opt-in-not-required"),
+											},
+											{
+												RegionName:  aws.String("me-south-1"),
+												OptInStatus: aws.String("not-opted-in"),
+											},
+											{
+												RegionName:  aws.String("ap-east-1"),
+												OptInStatus: aws.String("opted-in"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: []Region{
+				{Name: "ap-east-1", OptInStatus: RegionOptedIn, Partition: PartitionAWS},
+				{Name: "me-south-1", OptInStatus: RegionNotOptedIn, Partition: PartitionAWS},
+				{Name: "us-east-1", OptInStatus: RegionOptInNotRequired, Partition: PartitionAWS},
 			},
 			wantErr: false,
 		},
@@ -108,7 +148,7 @@ func TestEC2_DescribeRegions(t *testing.T) {
 					)
 				},
 			},
-			want:    []string{},
+			want:    []Region{},
 			wantErr: true,
 		},
 	}
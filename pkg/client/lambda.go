@@ -3,19 +3,29 @@ package client
 
 import (
 	"context"
-	"regexp"
+	"errors"
 	"sort"
-	"strconv"
-	"strings"
 
+	"github.com/go-to-k/lamver/pkg/runtimeversion"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
 
+// LambdaTimestampLayout is the layout of timestamps such as LastModified returned by Lambda APIs.
+const LambdaTimestampLayout = "2006-01-02T15:04:05.000-0700"
+
 type LambdaClient interface {
 	ListFunctions(ctx context.Context) ([]types.FunctionConfiguration, error)
 	ListFunctionsWithRegion(ctx context.Context, region string) ([]types.FunctionConfiguration, error)
 	ListRuntimeValues() []string
+	ListTagsWithRegion(ctx context.Context, region string, functionArn string) (map[string]string, error)
+	ListEventSourceMappingsWithRegion(ctx context.Context, region string) ([]types.EventSourceMappingConfiguration, error)
+	GetPolicyWithRegion(ctx context.Context, region string, functionName string) (string, error)
+	ListFunctionUrlConfigsWithRegion(ctx context.Context, region string, functionName string) ([]types.FunctionUrlConfig, error)
+	GetFunctionCodeSigningConfigWithRegion(ctx context.Context, region string, functionName string) (string, error)
+	ListLayerVersionsWithRegion(ctx context.Context, region string, layerName string) ([]types.LayerVersionsListItem, error)
 }
 
 type Lambda struct {
@@ -76,110 +86,197 @@ func (c *Lambda) ListFunctionsWithRegion(ctx context.Context, region string) ([]
 	return outputs, nil
 }
 
-func (c *Lambda) ListRuntimeValues() []string {
-	var r types.Runtime
-	runtimeStrList := []string{}
-	runtimeList := r.Values()
+func (c *Lambda) ListTagsWithRegion(ctx context.Context, region string, functionArn string) (map[string]string, error) {
+	input := &lambda.ListTagsInput{
+		Resource: &functionArn,
+	}
 
-	sort.Slice(runtimeList, func(i, j int) bool {
-		first := string(runtimeList[i])
-		second := string(runtimeList[j])
+	var (
+		output *lambda.ListTagsOutput
+		err    error
+	)
 
-		firstRuntime, firstVersion, firstRest := c.splitVersion(first)
-		secondRuntime, secondVersion, secondRest := c.splitVersion(second)
+	if region == "" {
+		output, err = c.client.ListTags(ctx, input)
+	} else {
+		output, err = c.client.ListTags(ctx, input, func(o *lambda.Options) {
+			o.Region = region
+		})
+	}
+	if err != nil {
+		return map[string]string{}, err
+	}
 
-		if firstRuntime != secondRuntime {
-			return firstRuntime < secondRuntime
-		}
+	if output.Tags == nil {
+		return map[string]string{}, nil
+	}
+	return output.Tags, nil
+}
 
-		if hasFinished, shouldSorted := c.compareActualVersion(firstVersion, secondVersion); hasFinished {
-			return shouldSorted
-		}
+// ListEventSourceMappingsWithRegion lists all event source mappings in the region.
+func (c *Lambda) ListEventSourceMappingsWithRegion(ctx context.Context, region string) ([]types.EventSourceMappingConfiguration, error) {
+	var nextMarker *string
+	outputs := []types.EventSourceMappingConfiguration{}
+
+	var optFns []func(*lambda.Options)
+	if region != "" {
+		optFns = append(optFns, func(o *lambda.Options) {
+			o.Region = region
+		})
+	}
 
-		if firstRest == "" {
-			return true
+	for {
+		input := &lambda.ListEventSourceMappingsInput{
+			Marker: nextMarker,
 		}
-		if secondRest == "" {
-			return false
+
+		output, err := c.client.ListEventSourceMappings(ctx, input, optFns...)
+		if err != nil {
+			return outputs, err
 		}
 
-		return firstRest < secondRest
-	})
+		outputs = append(outputs, output.EventSourceMappings...)
 
-	for _, runtime := range runtimeList {
-		runtimeStrList = append(runtimeStrList, string(runtime))
+		nextMarker = output.NextMarker
+
+		if nextMarker == nil {
+			break
+		}
 	}
 
-	return runtimeStrList
+	return outputs, nil
 }
 
-func (c *Lambda) splitVersion(runtimeStr string) (string, string, string) {
-	r := regexp.MustCompile(`^(\D+)([\d\.]+)?(.*)?$`)
-	matches := r.FindStringSubmatch(runtimeStr)
-
-	runtime := ""
-	version := ""
-	rest := ""
-
-	if len(matches) > 1 {
-		runtime = matches[1]
+// GetPolicyWithRegion returns the resource-based policy document of the function.
+// An empty string is returned if the function has no policy.
+func (c *Lambda) GetPolicyWithRegion(ctx context.Context, region string, functionName string) (string, error) {
+	input := &lambda.GetPolicyInput{
+		FunctionName: &functionName,
 	}
-	if len(matches) > 2 {
-		version = matches[2]
+
+	var optFns []func(*lambda.Options)
+	if region != "" {
+		optFns = append(optFns, func(o *lambda.Options) {
+			o.Region = region
+		})
 	}
-	if len(matches) > 3 {
-		rest = matches[3]
+
+	output, err := c.client.GetPolicy(ctx, input, optFns...)
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
 	}
 
-	return runtime, version, rest
+	return aws.ToString(output.Policy), nil
 }
 
-func (c *Lambda) compareActualVersion(first string, second string) (hasFinished bool, shouldSorted bool) {
-	if first == "" {
-		return true, true
-	}
-	if second == "" {
-		return true, false
-	}
+// ListFunctionUrlConfigsWithRegion lists the function URLs of the function and its aliases.
+func (c *Lambda) ListFunctionUrlConfigsWithRegion(ctx context.Context, region string, functionName string) ([]types.FunctionUrlConfig, error) {
+	var nextMarker *string
+	outputs := []types.FunctionUrlConfig{}
 
-	if first[:len(first)-1] == "." {
-		first = first[len(first)-1:]
+	var optFns []func(*lambda.Options)
+	if region != "" {
+		optFns = append(optFns, func(o *lambda.Options) {
+			o.Region = region
+		})
 	}
-	if second[:len(second)-1] == "." {
-		second = second[len(second)-1:]
+
+	for {
+		input := &lambda.ListFunctionUrlConfigsInput{
+			FunctionName: &functionName,
+			Marker:       nextMarker,
+		}
+
+		output, err := c.client.ListFunctionUrlConfigs(ctx, input, optFns...)
+		if err != nil {
+			return outputs, err
+		}
+
+		outputs = append(outputs, output.FunctionUrlConfigs...)
+
+		nextMarker = output.NextMarker
+
+		if nextMarker == nil {
+			break
+		}
 	}
 
-	firstIntegers := first
-	firstDecimals := ""
-	secondIntegers := second
-	secondDecimals := ""
+	return outputs, nil
+}
 
-	if before, after, ok := strings.Cut(first, "."); ok {
-		firstIntegers = before
-		firstDecimals = after
+// GetFunctionCodeSigningConfigWithRegion returns the ARN of the code signing config of the function.
+// An empty string is returned if the function has no code signing config.
+func (c *Lambda) GetFunctionCodeSigningConfigWithRegion(ctx context.Context, region string, functionName string) (string, error) {
+	input := &lambda.GetFunctionCodeSigningConfigInput{
+		FunctionName: &functionName,
 	}
-	if before, after, ok := strings.Cut(second, "."); ok {
-		secondIntegers = before
-		secondDecimals = after
+
+	var optFns []func(*lambda.Options)
+	if region != "" {
+		optFns = append(optFns, func(o *lambda.Options) {
+			o.Region = region
+		})
 	}
 
-	if firstIntegers != secondIntegers {
-		fInt, _ := strconv.Atoi(firstIntegers)
-		sInt, _ := strconv.Atoi(secondIntegers)
-		return true, fInt < sInt
+	output, err := c.client.GetFunctionCodeSigningConfig(ctx, input, optFns...)
+	if err != nil {
+		return "", err
 	}
 
-	if firstDecimals == "" && secondDecimals != "" {
-		return true, true
+	return aws.ToString(output.CodeSigningConfigArn), nil
+}
+
+// ListLayerVersionsWithRegion lists the versions of the layer. layerName can be the name or the ARN of the layer.
+func (c *Lambda) ListLayerVersionsWithRegion(ctx context.Context, region string, layerName string) ([]types.LayerVersionsListItem, error) {
+	var nextMarker *string
+	outputs := []types.LayerVersionsListItem{}
+
+	var optFns []func(*lambda.Options)
+	if region != "" {
+		optFns = append(optFns, func(o *lambda.Options) {
+			o.Region = region
+		})
 	}
-	if firstDecimals != "" && secondDecimals == "" {
-		return true, false
+
+	for {
+		input := &lambda.ListLayerVersionsInput{
+			LayerName: &layerName,
+			Marker:    nextMarker,
+		}
+
+		output, err := c.client.ListLayerVersions(ctx, input, optFns...)
+		if err != nil {
+			return outputs, err
+		}
+
+		outputs = append(outputs, output.LayerVersions...)
+
+		nextMarker = output.NextMarker
+
+		if nextMarker == nil {
+			break
+		}
 	}
-	if firstDecimals != secondDecimals {
-		fDec, _ := strconv.Atoi(firstDecimals)
-		sDec, _ := strconv.Atoi(secondDecimals)
-		return true, fDec < sDec
+
+	return outputs, nil
+}
+
+func (c *Lambda) ListRuntimeValues() []string {
+	var r types.Runtime
+	runtimeStrList := []string{}
+	runtimeList := r.Values()
+
+	sort.Slice(runtimeList, func(i, j int) bool {
+		return runtimeversion.Less(string(runtimeList[i]), string(runtimeList[j]))
+	})
+
+	for _, runtime := range runtimeList {
+		runtimeStrList = append(runtimeStrList, string(runtime))
 	}
 
-	return false, false
+	return runtimeStrList
 }
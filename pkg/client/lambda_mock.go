@@ -4,6 +4,8 @@
 // Generated by this command:
 //
 //	mockgen -source=lambda.go -destination=lambda_mock.go -package=client -write_package_comment=false
+//
+
 package client
 
 import (
@@ -18,6 +20,7 @@ import (
 type MockLambdaClient struct {
 	ctrl     *gomock.Controller
 	recorder *MockLambdaClientMockRecorder
+	isgomock struct{}
 }
 
 // MockLambdaClientMockRecorder is the mock recorder for MockLambdaClient.
@@ -37,6 +40,66 @@ func (m *MockLambdaClient) EXPECT() *MockLambdaClientMockRecorder {
 	return m.recorder
 }
 
+// GetFunctionCodeSigningConfigWithRegion mocks base method.
+func (m *MockLambdaClient) GetFunctionCodeSigningConfigWithRegion(ctx context.Context, region, functionName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFunctionCodeSigningConfigWithRegion", ctx, region, functionName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionCodeSigningConfigWithRegion indicates an expected call of GetFunctionCodeSigningConfigWithRegion.
+func (mr *MockLambdaClientMockRecorder) GetFunctionCodeSigningConfigWithRegion(ctx, region, functionName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionCodeSigningConfigWithRegion", reflect.TypeOf((*MockLambdaClient)(nil).GetFunctionCodeSigningConfigWithRegion), ctx, region, functionName)
+}
+
+// GetPolicyWithRegion mocks base method.
+func (m *MockLambdaClient) GetPolicyWithRegion(ctx context.Context, region, functionName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyWithRegion", ctx, region, functionName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyWithRegion indicates an expected call of GetPolicyWithRegion.
+func (mr *MockLambdaClientMockRecorder) GetPolicyWithRegion(ctx, region, functionName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyWithRegion", reflect.TypeOf((*MockLambdaClient)(nil).GetPolicyWithRegion), ctx, region, functionName)
+}
+
+// ListEventSourceMappingsWithRegion mocks base method.
+func (m *MockLambdaClient) ListEventSourceMappingsWithRegion(ctx context.Context, region string) ([]types.EventSourceMappingConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventSourceMappingsWithRegion", ctx, region)
+	ret0, _ := ret[0].([]types.EventSourceMappingConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventSourceMappingsWithRegion indicates an expected call of ListEventSourceMappingsWithRegion.
+func (mr *MockLambdaClientMockRecorder) ListEventSourceMappingsWithRegion(ctx, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventSourceMappingsWithRegion", reflect.TypeOf((*MockLambdaClient)(nil).ListEventSourceMappingsWithRegion), ctx, region)
+}
+
+// ListFunctionUrlConfigsWithRegion mocks base method.
+func (m *MockLambdaClient) ListFunctionUrlConfigsWithRegion(ctx context.Context, region, functionName string) ([]types.FunctionUrlConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFunctionUrlConfigsWithRegion", ctx, region, functionName)
+	ret0, _ := ret[0].([]types.FunctionUrlConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFunctionUrlConfigsWithRegion indicates an expected call of ListFunctionUrlConfigsWithRegion.
+func (mr *MockLambdaClientMockRecorder) ListFunctionUrlConfigsWithRegion(ctx, region, functionName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionUrlConfigsWithRegion", reflect.TypeOf((*MockLambdaClient)(nil).ListFunctionUrlConfigsWithRegion), ctx, region, functionName)
+}
+
 // ListFunctions mocks base method.
 func (m *MockLambdaClient) ListFunctions(ctx context.Context) ([]types.FunctionConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -67,6 +130,21 @@ func (mr *MockLambdaClientMockRecorder) ListFunctionsWithRegion(ctx, region any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFunctionsWithRegion", reflect.TypeOf((*MockLambdaClient)(nil).ListFunctionsWithRegion), ctx, region)
 }
 
+// ListLayerVersionsWithRegion mocks base method.
+func (m *MockLambdaClient) ListLayerVersionsWithRegion(ctx context.Context, region, layerName string) ([]types.LayerVersionsListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLayerVersionsWithRegion", ctx, region, layerName)
+	ret0, _ := ret[0].([]types.LayerVersionsListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLayerVersionsWithRegion indicates an expected call of ListLayerVersionsWithRegion.
+func (mr *MockLambdaClientMockRecorder) ListLayerVersionsWithRegion(ctx, region, layerName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLayerVersionsWithRegion", reflect.TypeOf((*MockLambdaClient)(nil).ListLayerVersionsWithRegion), ctx, region, layerName)
+}
+
 // ListRuntimeValues mocks base method.
 func (m *MockLambdaClient) ListRuntimeValues() []string {
 	m.ctrl.T.Helper()
@@ -80,3 +158,18 @@ func (mr *MockLambdaClientMockRecorder) ListRuntimeValues() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuntimeValues", reflect.TypeOf((*MockLambdaClient)(nil).ListRuntimeValues))
 }
+
+// ListTagsWithRegion mocks base method.
+func (m *MockLambdaClient) ListTagsWithRegion(ctx context.Context, region, functionArn string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTagsWithRegion", ctx, region, functionArn)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTagsWithRegion indicates an expected call of ListTagsWithRegion.
+func (mr *MockLambdaClientMockRecorder) ListTagsWithRegion(ctx, region, functionArn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTagsWithRegion", reflect.TypeOf((*MockLambdaClient)(nil).ListTagsWithRegion), ctx, region, functionArn)
+}
@@ -588,6 +588,706 @@ func TestLambda_ListFunctionsWithRegion(t *testing.T) {
 	}
 }
 
+func TestLambda_ListTagsWithRegion(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		region             string
+		functionArn        string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "ListTagsWithRegion success",
+			args: args{
+				ctx:         context.Background(),
+				region:      "us-east-1",
+				functionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListTagsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListTagsOutput{
+										Tags: map[string]string{
+											"team": "core",
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: map[string]string{
+				"team": "core",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListTagsWithRegion with no tags success",
+			args: args{
+				ctx:         context.Background(),
+				region:      "",
+				functionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListTagsWithNoTagsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListTagsOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    map[string]string{},
+			wantErr: false,
+		},
+		{
+			name: "ListTagsWithRegion fail",
+			args: args{
+				ctx:         context.Background(),
+				region:      "us-east-1",
+				functionArn: "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListTagsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListTagsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListTagsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    map[string]string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := lambda.NewFromConfig(cfg)
+			lambdaClient := NewLambda(client)
+
+			got, err := lambdaClient.ListTagsWithRegion(tt.args.ctx, tt.args.region, tt.args.functionArn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lambda.ListTagsWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lambda.ListTagsWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLambda_ListEventSourceMappingsWithRegion(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		region             string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.EventSourceMappingConfiguration
+		wantErr bool
+	}{
+		{
+			name: "ListEventSourceMappingsWithRegion success",
+			args: args{
+				ctx:    context.Background(),
+				region: "us-east-1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListEventSourceMappingsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListEventSourceMappingsOutput{
+										EventSourceMappings: []types.EventSourceMappingConfiguration{
+											{
+												EventSourceArn: aws.String("arn:aws:sqs:us-east-1:123456789012:Queue1"),
+												FunctionArn:    aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function1"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: []types.EventSourceMappingConfiguration{
+				{
+					EventSourceArn: aws.String("arn:aws:sqs:us-east-1:123456789012:Queue1"),
+					FunctionArn:    aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function1"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListEventSourceMappingsWithRegion with no mappings success",
+			args: args{
+				ctx:    context.Background(),
+				region: "",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListEventSourceMappingsEmptyMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListEventSourceMappingsOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.EventSourceMappingConfiguration{},
+			wantErr: false,
+		},
+		{
+			name: "ListEventSourceMappingsWithRegion fail",
+			args: args{
+				ctx:    context.Background(),
+				region: "us-east-1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListEventSourceMappingsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListEventSourceMappingsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListEventSourceMappingsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.EventSourceMappingConfiguration{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := lambda.NewFromConfig(cfg)
+			lambdaClient := NewLambda(client)
+
+			got, err := lambdaClient.ListEventSourceMappingsWithRegion(tt.args.ctx, tt.args.region)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lambda.ListEventSourceMappingsWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lambda.ListEventSourceMappingsWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLambda_GetPolicyWithRegion(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		region             string
+		functionName       string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "GetPolicyWithRegion success",
+			args: args{
+				ctx:          context.Background(),
+				region:       "us-east-1",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetPolicyMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.GetPolicyOutput{
+										Policy: aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    `{"Version":"2012-10-17","Statement":[]}`,
+			wantErr: false,
+		},
+		{
+			name: "GetPolicyWithRegion with no policy success",
+			args: args{
+				ctx:          context.Background(),
+				region:       "us-east-1",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetPolicyNotFoundMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.GetPolicyOutput{},
+								}, middleware.Metadata{}, &types.ResourceNotFoundException{Message: aws.String("The resource you requested does not exist.")}
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name: "GetPolicyWithRegion fail",
+			args: args{
+				ctx:          context.Background(),
+				region:       "",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetPolicyErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.GetPolicyOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("GetPolicyError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := lambda.NewFromConfig(cfg)
+			lambdaClient := NewLambda(client)
+
+			got, err := lambdaClient.GetPolicyWithRegion(tt.args.ctx, tt.args.region, tt.args.functionName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lambda.GetPolicyWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Lambda.GetPolicyWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLambda_ListFunctionUrlConfigsWithRegion(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		region             string
+		functionName       string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.FunctionUrlConfig
+		wantErr bool
+	}{
+		{
+			name: "ListFunctionUrlConfigsWithRegion success",
+			args: args{
+				ctx:          context.Background(),
+				region:       "us-east-1",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListFunctionUrlConfigsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListFunctionUrlConfigsOutput{
+										FunctionUrlConfigs: []types.FunctionUrlConfig{
+											{
+												AuthType:    types.FunctionUrlAuthTypeNone,
+												FunctionUrl: aws.String("https://abcdefg.lambda-url.us-east-1.on.aws/"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: []types.FunctionUrlConfig{
+				{
+					AuthType:    types.FunctionUrlAuthTypeNone,
+					FunctionUrl: aws.String("https://abcdefg.lambda-url.us-east-1.on.aws/"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListFunctionUrlConfigsWithRegion with no function URLs success",
+			args: args{
+				ctx:          context.Background(),
+				region:       "",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListFunctionUrlConfigsEmptyMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListFunctionUrlConfigsOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.FunctionUrlConfig{},
+			wantErr: false,
+		},
+		{
+			name: "ListFunctionUrlConfigsWithRegion fail",
+			args: args{
+				ctx:          context.Background(),
+				region:       "us-east-1",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListFunctionUrlConfigsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListFunctionUrlConfigsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListFunctionUrlConfigsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.FunctionUrlConfig{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := lambda.NewFromConfig(cfg)
+			lambdaClient := NewLambda(client)
+
+			got, err := lambdaClient.ListFunctionUrlConfigsWithRegion(tt.args.ctx, tt.args.region, tt.args.functionName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lambda.ListFunctionUrlConfigsWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lambda.ListFunctionUrlConfigsWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLambda_GetFunctionCodeSigningConfigWithRegion(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		region             string
+		functionName       string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "GetFunctionCodeSigningConfigWithRegion success",
+			args: args{
+				ctx:          context.Background(),
+				region:       "us-east-1",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetFunctionCodeSigningConfigMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.GetFunctionCodeSigningConfigOutput{
+										CodeSigningConfigArn: aws.String("arn:aws:lambda:us-east-1:123456789012:code-signing-config:csc-0123456789abcdef0"),
+										FunctionName:         aws.String("Function1"),
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    "arn:aws:lambda:us-east-1:123456789012:code-signing-config:csc-0123456789abcdef0",
+			wantErr: false,
+		},
+		{
+			name: "GetFunctionCodeSigningConfigWithRegion with no code signing config success",
+			args: args{
+				ctx:          context.Background(),
+				region:       "",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetFunctionCodeSigningConfigEmptyMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.GetFunctionCodeSigningConfigOutput{
+										CodeSigningConfigArn: aws.String(""),
+										FunctionName:         aws.String("Function1"),
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name: "GetFunctionCodeSigningConfigWithRegion fail",
+			args: args{
+				ctx:          context.Background(),
+				region:       "us-east-1",
+				functionName: "Function1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetFunctionCodeSigningConfigErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.GetFunctionCodeSigningConfigOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("GetFunctionCodeSigningConfigError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := lambda.NewFromConfig(cfg)
+			lambdaClient := NewLambda(client)
+
+			got, err := lambdaClient.GetFunctionCodeSigningConfigWithRegion(tt.args.ctx, tt.args.region, tt.args.functionName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lambda.GetFunctionCodeSigningConfigWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Lambda.GetFunctionCodeSigningConfigWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLambda_ListLayerVersionsWithRegion(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		region             string
+		layerName          string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.LayerVersionsListItem
+		wantErr bool
+	}{
+		{
+			name: "ListLayerVersionsWithRegion success",
+			args: args{
+				ctx:       context.Background(),
+				region:    "us-east-1",
+				layerName: "arn:aws:lambda:us-east-1:123456789012:layer:Layer1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListLayerVersionsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListLayerVersionsOutput{
+										LayerVersions: []types.LayerVersionsListItem{
+											{
+												LayerVersionArn:         aws.String("arn:aws:lambda:us-east-1:123456789012:layer:Layer1:1"),
+												CompatibleArchitectures: []types.Architecture{types.ArchitectureX8664, types.ArchitectureArm64},
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: []types.LayerVersionsListItem{
+				{
+					LayerVersionArn:         aws.String("arn:aws:lambda:us-east-1:123456789012:layer:Layer1:1"),
+					CompatibleArchitectures: []types.Architecture{types.ArchitectureX8664, types.ArchitectureArm64},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ListLayerVersionsWithRegion with no layer versions success",
+			args: args{
+				ctx:       context.Background(),
+				region:    "",
+				layerName: "arn:aws:lambda:us-east-1:123456789012:layer:Layer1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListLayerVersionsEmptyMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListLayerVersionsOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.LayerVersionsListItem{},
+			wantErr: false,
+		},
+		{
+			name: "ListLayerVersionsWithRegion fail",
+			args: args{
+				ctx:       context.Background(),
+				region:    "us-east-1",
+				layerName: "arn:aws:lambda:us-east-1:123456789012:layer:Layer1",
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListLayerVersionsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &lambda.ListLayerVersionsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListLayerVersionsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.LayerVersionsListItem{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := lambda.NewFromConfig(cfg)
+			lambdaClient := NewLambda(client)
+
+			got, err := lambdaClient.ListLayerVersionsWithRegion(tt.args.ctx, tt.args.region, tt.args.layerName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lambda.ListLayerVersionsWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lambda.ListLayerVersionsWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLambda_ListRuntimeValues(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	PartitionAWS      = "aws"
+	PartitionAWSUSGov = "aws-us-gov"
+	PartitionAWSCN    = "aws-cn"
+)
+
+var defaultRegionByPartition = map[string]string{
+	PartitionAWS:      DefaultAwsRegion,
+	PartitionAWSUSGov: "us-gov-west-1",
+	PartitionAWSCN:    "cn-north-1",
+}
+
+func GetPartitions() []string {
+	return []string{PartitionAWS, PartitionAWSUSGov, PartitionAWSCN}
+}
+
+func ValidatePartition(partition string) error {
+	if _, ok := defaultRegionByPartition[partition]; !ok {
+		return fmt.Errorf("invalid partition: %s, must be one of %s", partition, strings.Join(GetPartitions(), ", "))
+	}
+	return nil
+}
+
+func GetPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionAWSUSGov
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionAWSCN
+	default:
+		return PartitionAWS
+	}
+}
+
+func GetDefaultRegion(partition string) string {
+	if region, ok := defaultRegionByPartition[partition]; ok {
+		return region
+	}
+	return DefaultAwsRegion
+}
+
+// GetConsoleHost returns the host of the AWS Management Console for the region.
+func GetConsoleHost(region string) string {
+	switch GetPartition(region) {
+	case PartitionAWSUSGov:
+		return "console.amazonaws-us-gov.com"
+	case PartitionAWSCN:
+		return "console.amazonaws.cn"
+	default:
+		return region + ".console.aws.amazon.com"
+	}
+}
+
+func GetLambdaConsoleURL(region string, functionName string) string {
+	return fmt.Sprintf("https://%s/lambda/home?region=%s#/functions/%s", GetConsoleHost(region), region, functionName)
+}
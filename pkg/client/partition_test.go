@@ -0,0 +1,169 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestGetPartition(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{
+			name:   "GetPartition for commercial region",
+			region: "ap-northeast-1",
+			want:   PartitionAWS,
+		},
+		{
+			name:   "GetPartition for GovCloud region",
+			region: "us-gov-west-1",
+			want:   PartitionAWSUSGov,
+		},
+		{
+			name:   "GetPartition for China region",
+			region: "cn-northwest-1",
+			want:   PartitionAWSCN,
+		},
+		{
+			name:   "GetPartition for empty region",
+			region: "",
+			want:   PartitionAWS,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetPartition(tt.region); got != tt.want {
+				t.Errorf("GetPartition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDefaultRegion(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition string
+		want      string
+	}{
+		{
+			name:      "GetDefaultRegion for aws",
+			partition: PartitionAWS,
+			want:      "us-east-1",
+		},
+		{
+			name:      "GetDefaultRegion for aws-us-gov",
+			partition: PartitionAWSUSGov,
+			want:      "us-gov-west-1",
+		},
+		{
+			name:      "GetDefaultRegion for aws-cn",
+			partition: PartitionAWSCN,
+			want:      "cn-north-1",
+		},
+		{
+			name:      "GetDefaultRegion if partition is empty",
+			partition: "",
+			want:      "us-east-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetDefaultRegion(tt.partition); got != tt.want {
+				t.Errorf("GetDefaultRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePartition(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition string
+		wantErr   bool
+	}{
+		{
+			name:      "ValidatePartition success",
+			partition: PartitionAWSUSGov,
+			wantErr:   false,
+		},
+		{
+			name:      "ValidatePartition fail with unknown partition",
+			partition: "aws-iso",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePartition(tt.partition); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePartition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetConsoleHost(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{
+			name:   "GetConsoleHost for commercial region",
+			region: "us-east-2",
+			want:   "us-east-2.console.aws.amazon.com",
+		},
+		{
+			name:   "GetConsoleHost for GovCloud region",
+			region: "us-gov-east-1",
+			want:   "console.amazonaws-us-gov.com",
+		},
+		{
+			name:   "GetConsoleHost for China region",
+			region: "cn-north-1",
+			want:   "console.amazonaws.cn",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetConsoleHost(tt.region); got != tt.want {
+				t.Errorf("GetConsoleHost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLambdaConsoleURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		region       string
+		functionName string
+		want         string
+	}{
+		{
+			name:         "GetLambdaConsoleURL for commercial region",
+			region:       "ap-northeast-1",
+			functionName: "Function1",
+			want:         "https://ap-northeast-1.console.aws.amazon.com/lambda/home?region=ap-northeast-1#/functions/Function1",
+		},
+		{
+			name:         "GetLambdaConsoleURL for GovCloud region",
+			region:       "us-gov-west-1",
+			functionName: "Function1",
+			want:         "https://console.amazonaws-us-gov.com/lambda/home?region=us-gov-west-1#/functions/Function1",
+		},
+		{
+			name:         "GetLambdaConsoleURL for China region",
+			region:       "cn-north-1",
+			functionName: "Function1",
+			want:         "https://console.amazonaws.cn/lambda/home?region=cn-north-1#/functions/Function1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetLambdaConsoleURL(tt.region, tt.functionName); got != tt.want {
+				t.Errorf("GetLambdaConsoleURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
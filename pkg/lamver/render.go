@@ -0,0 +1,73 @@
+package lamver
+
+import (
+	"github.com/go-to-k/lamver/internal/io"
+	"github.com/go-to-k/lamver/internal/types"
+
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// Table is the search result to be rendered, as the header and the rows of cell values.
+type Table struct {
+	Header []string
+	// Rows are the cell values as text, for text formats such as a table.
+	Rows [][]string
+	// Records are the cell values as typed values, for structured formats such as JSON: a string,
+	// a number such as int64 for CodeSize, a list of strings, or nil for an empty value.
+	Records [][]any
+}
+
+// Renderer renders search results into an output format.
+type Renderer interface {
+	Render(table *Table) ([]byte, error)
+}
+
+// RegisterRenderer registers a renderer for the format, replacing any renderer already registered for it,
+// including the built-in ones. The format can then be passed to Render.
+func RegisterRenderer(format string, renderer Renderer) {
+	io.RegisterRenderer(format, &rendererAdapter{renderer: renderer})
+}
+
+// Formats returns the registered formats, such as "csv" and "json", sorted by the name.
+func Formats() []string {
+	return io.GetFormats()
+}
+
+// Render renders the functions in the format, with the same columns as the lamver command.
+// The built-in JSON and YAML formats keep numbers as numbers and empty values as null.
+func Render(format string, functions []Function) ([]byte, error) {
+	renderer, err := io.GetRenderer(format)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]types.LambdaFunctionData, 0, len(functions))
+	for _, f := range functions {
+		data = append(data, types.LambdaFunctionData{
+			Runtime:      lambdaTypes.Runtime(f.Runtime),
+			Region:       f.Region,
+			FunctionName: f.FunctionName,
+			FunctionArn:  f.FunctionArn,
+			LastModified: f.LastModified,
+			CodeSize:     f.CodeSize,
+			ConsoleURL:   f.ConsoleURL,
+		})
+	}
+	return renderer.Render(&io.Result{
+		Columns:   io.GetDefaultColumns(),
+		Functions: data,
+	})
+}
+
+// rendererAdapter adapts a Renderer to the renderer of the lamver command.
+type rendererAdapter struct {
+	renderer Renderer
+}
+
+func (a *rendererAdapter) Render(result *io.Result) ([]byte, error) {
+	return a.renderer.Render(&Table{
+		Header:  result.Header(),
+		Rows:    result.Rows(),
+		Records: result.Records(),
+	})
+}
@@ -0,0 +1,129 @@
+package lamver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testRenderer struct{}
+
+func (r *testRenderer) Render(table *Table) ([]byte, error) {
+	lines := []string{strings.Join(table.Header, "|")}
+	for _, row := range table.Rows {
+		lines = append(lines, strings.Join(row, "|"))
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func TestRender(t *testing.T) {
+	RegisterRenderer("pipe", &testRenderer{})
+
+	functions := []Function{
+		{
+			Runtime:      "nodejs18.x",
+			Region:       "us-east-1",
+			FunctionName: "Function1",
+			FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+			LastModified: time.Date(2022, 12, 21, 9, 47, 43, 728000000, time.UTC),
+			CodeSize:     1024,
+			ConsoleURL:   "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function1",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Render success with a registered renderer",
+			format: "pipe",
+			want: "Runtime|Region|FunctionName|LastModified|CodeSize|FunctionArn|ConsoleURL\n" +
+				"nodejs18.x|us-east-1|Function1|2022-12-21T09:47:43.728+0000|1024|" + functions[0].FunctionArn + "|" + functions[0].ConsoleURL,
+		},
+		{
+			name:   "Render success with a built-in renderer",
+			format: "csv",
+			want: "Runtime,Region,FunctionName,LastModified,CodeSize,FunctionArn,ConsoleURL\n" +
+				"nodejs18.x,us-east-1,Function1,2022-12-21T09:47:43.728+0000,1024," + functions[0].FunctionArn + "," + functions[0].ConsoleURL + "\n",
+		},
+		{
+			name:    "Render fail with an unknown format",
+			format:  "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.format, functions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if formats := fmt.Sprint(Formats()); !strings.Contains(formats, "pipe") {
+		t.Errorf("Formats() = %v, want to contain pipe", formats)
+	}
+}
+
+// recordRenderer renders the types of the typed values.
+type recordRenderer struct{}
+
+func (r *recordRenderer) Render(table *Table) ([]byte, error) {
+	lines := []string{}
+	for _, record := range table.Records {
+		types := []string{}
+		for _, value := range record {
+			types = append(types, fmt.Sprintf("%T", value))
+		}
+		lines = append(lines, strings.Join(types, "|"))
+	}
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func TestRender_Typed(t *testing.T) {
+	RegisterRenderer("types", &recordRenderer{})
+
+	functions := []Function{
+		{
+			Runtime:      "nodejs18.x",
+			Region:       "us-east-1",
+			FunctionName: "Function1",
+			FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+			CodeSize:     1024,
+		},
+	}
+
+	got, err := Render("types", functions)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	// LastModified and ConsoleURL are empty
+	if want := "string|string|string|<nil>|int64|string|<nil>"; string(got) != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	out, err := Render("json", functions)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var records []struct {
+		FunctionName string  `json:"FunctionName"`
+		CodeSize     int64   `json:"CodeSize"`
+		LastModified *string `json:"LastModified"`
+	}
+	if err := json.Unmarshal(out, &records); err != nil {
+		t.Fatalf("failed to unmarshal the output: %v\n%s", err, out)
+	}
+	if len(records) != 1 || records[0].FunctionName != "Function1" || records[0].CodeSize != 1024 || records[0].LastModified != nil {
+		t.Errorf("Render() = %s, want CodeSize as a number and LastModified as null", out)
+	}
+}
@@ -0,0 +1,185 @@
+// Package lamver provides an API to search AWS Lambda functions by runtime values across regions.
+//
+// The exported API of this package follows semantic versioning.
+// Breaking changes are made only in a new major version.
+package lamver
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-to-k/lamver/internal/action"
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+const sdkRetryMaxAttempts = 3
+
+// Function is a Lambda function found by Search.
+type Function struct {
+	Runtime      string
+	Region       string
+	FunctionName string
+	FunctionArn  string
+	// LastModified is in UTC, or the zero time if the timestamp returned by Lambda cannot be parsed.
+	LastModified time.Time
+	CodeSize     int64
+	ConsoleURL   string
+}
+
+// Query specifies the functions to search.
+type Query struct {
+	// Regions to search. If empty, all regions enabled for the account are searched.
+	Regions []string
+	// Runtimes to search, such as "nodejs18.x". If empty, all runtime values are searched.
+	Runtimes []string
+	// Keyword filters functions by a part of the function name (case-insensitive).
+	Keyword string
+	// Tags filters functions that have all of the tags. An empty value matches any value of the key.
+	Tags map[string]string
+}
+
+// LambdaClient is the Lambda API called by a Searcher. It has only the methods that Search calls,
+// so that it does not change with pkg/client. client.LambdaClient implements it.
+type LambdaClient interface {
+	ListFunctionsWithRegion(ctx context.Context, region string) ([]lambdaTypes.FunctionConfiguration, error)
+	ListTagsWithRegion(ctx context.Context, region string, functionArn string) (map[string]string, error)
+	ListRuntimeValues() []string
+}
+
+// EC2Client is the EC2 API called by a Searcher to list regions. client.EC2Client implements it.
+type EC2Client interface {
+	DescribeRegions(ctx context.Context) ([]client.Region, error)
+}
+
+var (
+	_ LambdaClient = (client.LambdaClient)(nil)
+	_ EC2Client    = (client.EC2Client)(nil)
+)
+
+// Searcher searches Lambda functions across regions.
+type Searcher struct {
+	lambda      LambdaClient
+	ec2         EC2Client
+	concurrency int
+}
+
+// Option configures a Searcher.
+type Option func(*Searcher)
+
+// WithConcurrency sets the number of regions searched at the same time.
+// Defaults to the number of CPUs.
+func WithConcurrency(concurrency int) Option {
+	return func(s *Searcher) {
+		s.concurrency = concurrency
+	}
+}
+
+// WithLambdaClient replaces the client used to call Lambda APIs.
+func WithLambdaClient(lambdaClient LambdaClient) Option {
+	return func(s *Searcher) {
+		s.lambda = lambdaClient
+	}
+}
+
+// WithEC2Client replaces the client used to list regions.
+func WithEC2Client(ec2Client EC2Client) Option {
+	return func(s *Searcher) {
+		s.ec2 = ec2Client
+	}
+}
+
+// NewSearcher returns a Searcher that calls AWS APIs with the config.
+func NewSearcher(cfg aws.Config, opts ...Option) *Searcher {
+	s := &Searcher{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.lambda == nil {
+		s.lambda = client.NewLambda(
+			lambda.NewFromConfig(cfg, func(o *lambda.Options) {
+				o.RetryMaxAttempts = sdkRetryMaxAttempts
+				o.RetryMode = aws.RetryModeStandard
+			}),
+		)
+	}
+	if s.ec2 == nil {
+		s.ec2 = client.NewEC2(
+			ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+				o.RetryMaxAttempts = sdkRetryMaxAttempts
+				o.RetryMode = aws.RetryModeStandard
+			}),
+		)
+	}
+
+	return s
+}
+
+// Regions returns the names of the regions enabled for the account.
+func (s *Searcher) Regions(ctx context.Context) ([]string, error) {
+	regions, err := s.ec2.DescribeRegions(ctx)
+	if err != nil {
+		return []string{}, err
+	}
+
+	enabledRegions, _ := action.SplitRegionsByOptIn(regions)
+	regionNames := make([]string, 0, len(enabledRegions))
+	for _, region := range enabledRegions {
+		regionNames = append(regionNames, region.Name)
+	}
+	return regionNames, nil
+}
+
+// Search returns the functions matching the query, sorted by runtime, region and function name.
+func (s *Searcher) Search(ctx context.Context, query Query) ([]Function, error) {
+	regions := query.Regions
+	if len(regions) == 0 {
+		var err error
+		regions, err = s.Regions(ctx)
+		if err != nil {
+			return []Function{}, err
+		}
+	}
+
+	runtimes := query.Runtimes
+	if len(runtimes) == 0 {
+		runtimes = s.lambda.ListRuntimeValues()
+	}
+
+	functions, err := action.CreateFunctionList(&action.CreateFunctionListInput{
+		Ctx:           ctx,
+		TargetRegions: regions,
+		TargetRuntime: runtimes,
+		Keyword:       query.Keyword,
+		Tags:          query.Tags,
+		Concurrency:   s.concurrency,
+		Lambda:        s.lambda,
+	})
+	if err != nil {
+		return []Function{}, err
+	}
+
+	results := make([]Function, 0, len(functions))
+	for _, f := range functions {
+		lastModified := f.LastModified
+		if !lastModified.IsZero() {
+			lastModified = lastModified.UTC()
+		}
+		results = append(results, Function{
+			Runtime:      string(f.Runtime),
+			Region:       f.Region,
+			FunctionName: f.FunctionName,
+			FunctionArn:  f.FunctionArn,
+			LastModified: lastModified,
+			CodeSize:     f.CodeSize,
+			ConsoleURL:   f.ConsoleURL,
+		})
+	}
+
+	return results, nil
+}
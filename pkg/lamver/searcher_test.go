@@ -0,0 +1,167 @@
+package lamver
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-to-k/lamver/pkg/client"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	lambdaTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearcher_Search(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		query Query
+	}
+
+	tests := []struct {
+		name                      string
+		args                      args
+		prepareMockEC2ClientFn    func(m *client.MockEC2Client)
+		prepareMockLambdaClientFn func(m *client.MockLambdaClient)
+		want                      []Function
+		wantErr                   bool
+	}{
+		{
+			name: "Search success",
+			args: args{
+				ctx: context.Background(),
+				query: Query{
+					Regions:  []string{"us-east-1"},
+					Runtimes: []string{"nodejs18.x"},
+				},
+			},
+			prepareMockEC2ClientFn: func(m *client.MockEC2Client) {},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function1"),
+							FunctionArn:  aws.String("arn:aws:lambda:us-east-1:123456789012:function:Function1"),
+							Runtime:      lambdaTypes.RuntimeNodejs18x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+						{
+							FunctionName: aws.String("Function2"),
+							Runtime:      lambdaTypes.RuntimeGo1x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+			},
+			want: []Function{
+				{
+					Runtime:      "nodejs18.x",
+					Region:       "us-east-1",
+					FunctionName: "Function1",
+					FunctionArn:  "arn:aws:lambda:us-east-1:123456789012:function:Function1",
+					LastModified: time.Date(2022, 12, 21, 9, 47, 43, 728000000, time.UTC),
+					ConsoleURL:   "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function1",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Search success with all enabled regions and all runtime values if not specified",
+			args: args{
+				ctx:   context.Background(),
+				query: Query{},
+			},
+			prepareMockEC2ClientFn: func(m *client.MockEC2Client) {
+				m.EXPECT().DescribeRegions(gomock.Any()).Return(
+					[]client.Region{
+						{Name: "me-south-1", OptInStatus: client.RegionNotOptedIn},
+						{Name: "us-east-1", OptInStatus: client.RegionOptInNotRequired},
+					}, nil,
+				)
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListRuntimeValues().Return([]string{"go1.x", "nodejs18.x"})
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{
+						{
+							FunctionName: aws.String("Function2"),
+							Runtime:      lambdaTypes.RuntimeGo1x,
+							LastModified: aws.String("2022-12-21T09:47:43.728+0000"),
+						},
+					}, nil,
+				)
+			},
+			want: []Function{
+				{
+					Runtime:      "go1.x",
+					Region:       "us-east-1",
+					FunctionName: "Function2",
+					LastModified: time.Date(2022, 12, 21, 9, 47, 43, 728000000, time.UTC),
+					ConsoleURL:   "https://us-east-1.console.aws.amazon.com/lambda/home?region=us-east-1#/functions/Function2",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Search fail by DescribeRegions Error",
+			args: args{
+				ctx:   context.Background(),
+				query: Query{},
+			},
+			prepareMockEC2ClientFn: func(m *client.MockEC2Client) {
+				m.EXPECT().DescribeRegions(gomock.Any()).Return(
+					[]client.Region{}, fmt.Errorf("DescribeRegionsError"),
+				)
+			},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {},
+			want:                      []Function{},
+			wantErr:                   true,
+		},
+		{
+			name: "Search fail by ListFunctionsWithRegion Error",
+			args: args{
+				ctx: context.Background(),
+				query: Query{
+					Regions:  []string{"us-east-1"},
+					Runtimes: []string{"nodejs18.x"},
+				},
+			},
+			prepareMockEC2ClientFn: func(m *client.MockEC2Client) {},
+			prepareMockLambdaClientFn: func(m *client.MockLambdaClient) {
+				m.EXPECT().ListFunctionsWithRegion(gomock.Any(), "us-east-1").Return(
+					[]lambdaTypes.FunctionConfiguration{}, fmt.Errorf("ListFunctionsError"),
+				)
+			},
+			want:    []Function{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ec2ClientMock := client.NewMockEC2Client(ctrl)
+			lambdaClientMock := client.NewMockLambdaClient(ctrl)
+
+			tt.prepareMockEC2ClientFn(ec2ClientMock)
+			tt.prepareMockLambdaClientFn(lambdaClientMock)
+
+			searcher := NewSearcher(
+				aws.Config{Region: "us-east-1"},
+				WithEC2Client(ec2ClientMock),
+				WithLambdaClient(lambdaClientMock),
+				WithConcurrency(2),
+			)
+
+			got, err := searcher.Search(tt.args.ctx, tt.args.query)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Searcher.Search() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Searcher.Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
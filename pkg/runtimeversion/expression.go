@@ -0,0 +1,117 @@
+package runtimeversion
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	expressionPattern = regexp.MustCompile(`^([a-z]+)\s*(.*)$`)
+	constraintPattern = regexp.MustCompile(`^(<=|>=|==|!=|<|>|=)\s*(\d+(?:\.\d+)?)$`)
+)
+
+type constraint struct {
+	operator string
+	version  string
+}
+
+func (c constraint) match(version string) bool {
+	result := CompareVersions(version, c.version)
+	switch c.operator {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "!=":
+		return result != 0
+	default:
+		return result == 0
+	}
+}
+
+// Expression matches runtime values of a family whose version satisfies all the constraints.
+type Expression struct {
+	family      string
+	constraints []constraint
+}
+
+// ParseExpression parses an expression such as "nodejs<18", "python>=3.9,<3.11" or "java".
+// A family without constraints matches all versions of the family.
+// The operators are <, <=, >, >=, = (or ==) and !=.
+func ParseExpression(s string) (*Expression, error) {
+	s = strings.TrimSpace(s)
+	matches := expressionPattern.FindStringSubmatch(s)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid runtime expression: %q (e.g. nodejs<18, python>=3.9,<3.11)", s)
+	}
+
+	expression := &Expression{
+		family:      matches[1],
+		constraints: []constraint{},
+	}
+	if matches[2] == "" {
+		return expression, nil
+	}
+
+	for _, part := range strings.Split(matches[2], ",") {
+		part = strings.TrimSpace(part)
+		constraintMatches := constraintPattern.FindStringSubmatch(part)
+		if constraintMatches == nil {
+			return nil, fmt.Errorf("invalid version constraint %q in runtime expression: %q (e.g. <18, >=3.9)", part, s)
+		}
+		expression.constraints = append(expression.constraints, constraint{
+			operator: constraintMatches[1],
+			version:  constraintMatches[2],
+		})
+	}
+	return expression, nil
+}
+
+// ParseExpressions parses semicolon-separated expressions such as "nodejs<18; python<3.9".
+func ParseExpressions(s string) ([]*Expression, error) {
+	expressions := []*Expression{}
+	for _, part := range strings.Split(s, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		expression, err := ParseExpression(part)
+		if err != nil {
+			return expressions, err
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+// Match reports whether the runtime value matches the expression.
+func (e *Expression) Match(runtime string) bool {
+	if Family(runtime) != e.family {
+		return false
+	}
+	version := Version(runtime)
+	for _, c := range e.constraints {
+		if !c.match(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the runtime values matching any of the expressions, keeping the order.
+func Filter(runtimes []string, expressions []*Expression) []string {
+	filtered := []string{}
+	for _, runtime := range runtimes {
+		for _, expression := range expressions {
+			if expression.Match(runtime) {
+				filtered = append(filtered, runtime)
+				break
+			}
+		}
+	}
+	return filtered
+}
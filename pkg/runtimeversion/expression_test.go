@@ -0,0 +1,141 @@
+package runtimeversion
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testRuntimes = []string{
+	"java8",
+	"java8.al2",
+	"java11",
+	"java21",
+	"nodejs",
+	"nodejs4.3",
+	"nodejs16.x",
+	"nodejs18.x",
+	"nodejs20.x",
+	"python3.8",
+	"python3.9",
+	"python3.10",
+	"python3.11",
+	"python3.12",
+}
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []string
+		wantErr    bool
+	}{
+		{
+			name:       "ParseExpression with less than",
+			expression: "nodejs<18",
+			want:       []string{"nodejs", "nodejs4.3", "nodejs16.x"},
+			wantErr:    false,
+		},
+		{
+			name:       "ParseExpression with range",
+			expression: "python>=3.9,<3.11",
+			want:       []string{"python3.9", "python3.10"},
+			wantErr:    false,
+		},
+		{
+			name:       "ParseExpression with spaces",
+			expression: " python >= 3.9 , < 3.11 ",
+			want:       []string{"python3.9", "python3.10"},
+			wantErr:    false,
+		},
+		{
+			name:       "ParseExpression with equal",
+			expression: "java=8",
+			want:       []string{"java8", "java8.al2"},
+			wantErr:    false,
+		},
+		{
+			name:       "ParseExpression with not equal",
+			expression: "java!=8",
+			want:       []string{"java11", "java21"},
+			wantErr:    false,
+		},
+		{
+			name:       "ParseExpression with family only",
+			expression: "java",
+			want:       []string{"java8", "java8.al2", "java11", "java21"},
+			wantErr:    false,
+		},
+		{
+			name:       "ParseExpression fail with invalid operator",
+			expression: "nodejs=>18",
+			wantErr:    true,
+		},
+		{
+			name:       "ParseExpression fail with no version",
+			expression: "nodejs<",
+			wantErr:    true,
+		},
+		{
+			name:       "ParseExpression fail with no family",
+			expression: "<18",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExpression(tt.expression)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseExpression() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if filtered := Filter(testRuntimes, []*Expression{got}); !reflect.DeepEqual(filtered, tt.want) {
+				t.Errorf("ParseExpression() matches %v, want %v", filtered, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExpressions(t *testing.T) {
+	tests := []struct {
+		name        string
+		expressions string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "ParseExpressions with multiple expressions",
+			expressions: "nodejs<16; python<3.9",
+			want:        []string{"nodejs", "nodejs4.3", "python3.8"},
+			wantErr:     false,
+		},
+		{
+			name:        "ParseExpressions with empty string",
+			expressions: "",
+			want:        []string{},
+			wantErr:     false,
+		},
+		{
+			name:        "ParseExpressions fail with invalid expression",
+			expressions: "nodejs<16; python<<3.9",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExpressions(tt.expressions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseExpressions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if filtered := Filter(testRuntimes, got); !reflect.DeepEqual(filtered, tt.want) {
+				t.Errorf("ParseExpressions() matches %v, want %v", filtered, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package runtimeversion
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	fmt.Println()
+	fmt.Println("==========================================")
+	fmt.Println("======= Start Test: runtimeversion =======")
+	fmt.Println("==========================================")
+	goleak.VerifyTestMain(m)
+}
@@ -0,0 +1,118 @@
+// Package runtimeversion parses and compares Lambda runtime values such as "nodejs18.x" or "python3.12",
+// and filters them by expressions such as "nodejs<18" or "python>=3.9,<3.11".
+package runtimeversion
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	runtimePattern = regexp.MustCompile(`^(\D+)([\d\.]+)?(.*)?$`)
+	familyPattern  = regexp.MustCompile(`^[a-z]+`)
+)
+
+// Split splits a runtime value into the name, the version and the rest,
+// e.g. "nodejs18.x" into "nodejs", "18." and "x".
+func Split(runtime string) (name string, version string, rest string) {
+	matches := runtimePattern.FindStringSubmatch(runtime)
+
+	if len(matches) > 1 {
+		name = matches[1]
+	}
+	if len(matches) > 2 {
+		version = matches[2]
+	}
+	if len(matches) > 3 {
+		rest = matches[3]
+	}
+
+	return name, version, rest
+}
+
+// Family returns the leading letters of a runtime value, e.g. "nodejs" for "nodejs18.x"
+// and "provided" for "provided.al2".
+func Family(runtime string) string {
+	return familyPattern.FindString(runtime)
+}
+
+// Version returns the version of a runtime value without a trailing dot, e.g. "18" for "nodejs18.x"
+// and "3.12" for "python3.12". It is empty for runtime values without a version such as "nodejs".
+func Version(runtime string) string {
+	_, version, _ := Split(runtime)
+	return strings.TrimSuffix(version, ".")
+}
+
+// Less reports whether the runtime value first is sorted before second: by the name, by the version
+// compared numerically, and then by the rest.
+func Less(first string, second string) bool {
+	firstName, firstVersion, firstRest := Split(first)
+	secondName, secondVersion, secondRest := Split(second)
+
+	if firstName != secondName {
+		return firstName < secondName
+	}
+
+	if c := CompareVersions(firstVersion, secondVersion); c != 0 {
+		return c < 0
+	}
+
+	if firstRest == "" {
+		return true
+	}
+	if secondRest == "" {
+		return false
+	}
+
+	return firstRest < secondRest
+}
+
+// CompareVersions compares versions such as "3.9" and "3.11" numerically, by the integer part
+// and then the decimal part. It returns -1 if first is older, 1 if newer, and 0 if they are the same.
+// An empty version is older than any other version.
+func CompareVersions(first string, second string) int {
+	if first == "" && second == "" {
+		return 0
+	}
+	if first == "" {
+		return -1
+	}
+	if second == "" {
+		return 1
+	}
+
+	firstIntegers, firstDecimals, _ := strings.Cut(first, ".")
+	secondIntegers, secondDecimals, _ := strings.Cut(second, ".")
+
+	if firstIntegers != secondIntegers {
+		fInt, _ := strconv.Atoi(firstIntegers)
+		sInt, _ := strconv.Atoi(secondIntegers)
+		return compareInts(fInt, sInt)
+	}
+
+	if firstDecimals == "" && secondDecimals != "" {
+		return -1
+	}
+	if firstDecimals != "" && secondDecimals == "" {
+		return 1
+	}
+	if firstDecimals != secondDecimals {
+		fDec, _ := strconv.Atoi(firstDecimals)
+		sDec, _ := strconv.Atoi(secondDecimals)
+		return compareInts(fDec, sDec)
+	}
+
+	return 0
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
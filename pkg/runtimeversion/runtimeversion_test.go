@@ -0,0 +1,139 @@
+package runtimeversion
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name        string
+		runtime     string
+		wantName    string
+		wantVersion string
+		wantRest    string
+	}{
+		{
+			name:        "Split with minor version",
+			runtime:     "python3.12",
+			wantName:    "python",
+			wantVersion: "3.12",
+			wantRest:    "",
+		},
+		{
+			name:        "Split with x suffix",
+			runtime:     "nodejs18.x",
+			wantName:    "nodejs",
+			wantVersion: "18.",
+			wantRest:    "x",
+		},
+		{
+			name:        "Split with edge suffix",
+			runtime:     "nodejs4.3-edge",
+			wantName:    "nodejs",
+			wantVersion: "4.3",
+			wantRest:    "-edge",
+		},
+		{
+			name:        "Split without version",
+			runtime:     "nodejs",
+			wantName:    "nodejs",
+			wantVersion: "",
+			wantRest:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, gotVersion, gotRest := Split(tt.runtime)
+			if gotName != tt.wantName || gotVersion != tt.wantVersion || gotRest != tt.wantRest {
+				t.Errorf("Split() = %v, %v, %v, want %v, %v, %v", gotName, gotVersion, gotRest, tt.wantName, tt.wantVersion, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestFamilyAndVersion(t *testing.T) {
+	tests := []struct {
+		runtime     string
+		wantFamily  string
+		wantVersion string
+	}{
+		{runtime: "nodejs18.x", wantFamily: "nodejs", wantVersion: "18"},
+		{runtime: "python3.9", wantFamily: "python", wantVersion: "3.9"},
+		{runtime: "java8.al2", wantFamily: "java", wantVersion: "8"},
+		{runtime: "provided.al2023", wantFamily: "provided", wantVersion: "2023"},
+		{runtime: "dotnetcore3.1", wantFamily: "dotnetcore", wantVersion: "3.1"},
+		{runtime: "go1.x", wantFamily: "go", wantVersion: "1"},
+		{runtime: "nodejs", wantFamily: "nodejs", wantVersion: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			if got := Family(tt.runtime); got != tt.wantFamily {
+				t.Errorf("Family() = %v, want %v", got, tt.wantFamily)
+			}
+			if got := Version(tt.runtime); got != tt.wantVersion {
+				t.Errorf("Version() = %v, want %v", got, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  string
+		second string
+		want   int
+	}{
+		{name: "CompareVersions by integers", first: "8", second: "18", want: -1},
+		{name: "CompareVersions by decimals numerically", first: "3.11", second: "3.9", want: 1},
+		{name: "CompareVersions with same versions", first: "3.9", second: "3.9", want: 0},
+		{name: "CompareVersions without decimals first", first: "3", second: "3.9", want: -1},
+		{name: "CompareVersions without decimals second", first: "3.9", second: "3", want: 1},
+		{name: "CompareVersions with trailing dot", first: "18.", second: "18", want: 0},
+		{name: "CompareVersions with empty first", first: "", second: "4.3", want: -1},
+		{name: "CompareVersions with empty second", first: "4.3", second: "", want: 1},
+		{name: "CompareVersions with both empty", first: "", second: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareVersions(tt.first, tt.second); got != tt.want {
+				t.Errorf("CompareVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLess(t *testing.T) {
+	runtimes := []string{
+		"python3.12",
+		"nodejs18.x",
+		"java8.al2",
+		"nodejs",
+		"python3.9",
+		"nodejs4.3-edge",
+		"java8",
+		"nodejs4.3",
+		"java11",
+	}
+	want := []string{
+		"java8",
+		"java8.al2",
+		"java11",
+		"nodejs",
+		"nodejs4.3",
+		"nodejs4.3-edge",
+		"nodejs18.x",
+		"python3.9",
+		"python3.12",
+	}
+
+	sort.Slice(runtimes, func(i, j int) bool {
+		return Less(runtimes[i], runtimes[j])
+	})
+	for i := range want {
+		if runtimes[i] != want[i] {
+			t.Fatalf("sorted by Less = %v, want %v", runtimes, want)
+		}
+	}
+}